@@ -0,0 +1,47 @@
+package abci
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/keepers"
+	oracletypes "github.com/skip-mev/vote-ext-oracle-demo/x/oracle/types"
+)
+
+// OracleKeeper defines the subset of the oracle module's keeper that
+// VoteExtHandler and ProposalHandler depend on. Accepting this interface,
+// rather than a concrete keeper type, lets any implementation be injected at
+// construction. keepers.FauxOracleKeeper does not implement GetParams,
+// GetRewardPool or DistributeOracleRewards, so it does not satisfy it.
+type OracleKeeper interface {
+	// GetParams returns the oracle module's current governance-managed
+	// parameters.
+	GetParams(ctx sdk.Context) oracletypes.Params
+
+	// GetSupportedPairs returns every governance-approved currency pair.
+	GetSupportedPairs(ctx sdk.Context) []keepers.CurrencyPair
+
+	// IsProviderWhitelisted reports whether providerName may be used as an
+	// oracle price source.
+	IsProviderWhitelisted(ctx sdk.Context, providerName string) bool
+
+	// SetOraclePrices persists the finalized stake-weighted oracle prices for
+	// the current block.
+	SetOraclePrices(ctx sdk.Context, prices map[string]sdk.Dec) error
+
+	// HandleValidatorVote records whether the given validator participated
+	// in the oracle vote-extension protocol for the current block, slashing
+	// it if its rolling miss rate exceeds the configured threshold.
+	HandleValidatorVote(ctx sdk.Context, consAddr sdk.ConsAddress, power int64, missed bool) error
+
+	// PruneHistory deletes base's price history entries older than the
+	// configured retention window.
+	PruneHistory(ctx sdk.Context, base string)
+
+	// GetRewardPool returns the oracle module account's current balance, the
+	// pool DistributeOracleRewards pays out of.
+	GetRewardPool(ctx sdk.Context) sdk.Coins
+
+	// DistributeOracleRewards pays the reward pool out to candidates whose
+	// oracle vote-extension participation meets the configured threshold.
+	DistributeOracleRewards(ctx sdk.Context, pool sdk.Coins, candidates []oracletypes.RewardCandidate) error
+}
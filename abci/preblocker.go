@@ -0,0 +1,92 @@
+package abci
+
+import (
+	abci "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	oracletypes "github.com/skip-mev/vote-ext-oracle-demo/x/oracle/types"
+)
+
+// PreBlocker returns a handler that runs once per block, before the block's
+// transactions are delivered, to record each validator's oracle
+// vote-extension participation for the PREVIOUS block (i.e. the votes
+// decided by req.DecidedLastCommit) and slash validators whose miss rate has
+// grown too high.
+//
+// This mirrors ProcessProposal's computation of the stake-weighted prices
+// rather than trusting the (already-verified) injected tx, since PreBlocker
+// has no access to the proposal's injected vote extension tx.
+func (h *ProposalHandler) PreBlocker() sdk.PreBlocker {
+	return func(ctx sdk.Context, req *abci.RequestFinalizeBlock) (*sdk.ResponsePreBlock, error) {
+		h.refreshParams(ctx)
+
+		finalPrices, err := h.computeStakeWeightedOraclePrices(ctx, req.DecidedLastCommit)
+		if err != nil {
+			h.logger.Error("failed to compute stake-weighted oracle prices for participation tracking", "err", err)
+			return &sdk.ResponsePreBlock{}, nil
+		}
+
+		candidates := make([]oracletypes.RewardCandidate, 0, len(req.DecidedLastCommit.Votes))
+
+		for _, vote := range req.DecidedLastCommit.Votes {
+			missed := true
+
+			if len(vote.VoteExtension) > 0 {
+				if voteExt, err := h.codec.UnmarshalVoteExtension(vote.VoteExtension); err == nil {
+					missed = pricesDeviate(voteExt.Prices, finalPrices, h.maxVoteDeviation)
+				}
+			}
+
+			consAddr := sdk.ConsAddress(vote.Validator.Address)
+			if err := h.oracleKeeper.HandleValidatorVote(ctx, consAddr, vote.Validator.Power, missed); err != nil {
+				h.logger.Error("failed to handle validator oracle vote", "err", err, "validator", consAddr.String())
+			}
+
+			// NOTE: This demo has no staking keeper to map a validator's
+			// consensus address to the delegator/operator account that
+			// should receive its reward share, so for demo purposes we reuse
+			// the consensus address bytes as the payout account.
+			candidates = append(candidates, oracletypes.RewardCandidate{
+				ConsAddress: consAddr,
+				AccAddress:  sdk.AccAddress(consAddr),
+				Power:       vote.Validator.Power,
+			})
+		}
+
+		pool := h.oracleKeeper.GetRewardPool(ctx)
+		if err := h.oracleKeeper.DistributeOracleRewards(ctx, pool, candidates); err != nil {
+			h.logger.Error("failed to distribute oracle rewards", "err", err)
+		}
+
+		// Prune stale price history once per block rather than running a
+		// separate background routine, since PreBlocker already gives us a
+		// natural per-block hook into the oracle keeper.
+		for _, pair := range h.oracleKeeper.GetSupportedPairs(ctx) {
+			h.oracleKeeper.PruneHistory(ctx, pair.Base)
+		}
+
+		return &sdk.ResponsePreBlock{}, nil
+	}
+}
+
+// pricesDeviate reports whether voted diverges from final by more than
+// maxDeviation, relative to final, for any base both maps price. A base the
+// validator didn't vote on is skipped rather than counted as deviant, since
+// VerifyVoteExtension (not this check) is responsible for rejecting
+// incomplete vote extensions. A final price of zero is skipped as well,
+// since relative deviation is undefined.
+func pricesDeviate(voted, final map[string]sdk.Dec, maxDeviation sdk.Dec) bool {
+	for base, finalPrice := range final {
+		votedPrice, ok := voted[base]
+		if !ok || finalPrice.IsZero() {
+			continue
+		}
+
+		deviation := votedPrice.Sub(finalPrice).Abs().Quo(finalPrice)
+		if deviation.GT(maxDeviation) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,241 @@
+package abci
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/keepers"
+)
+
+// FailedPricesError is returned by computeOraclePrices when one or more, but
+// not necessarily all, base assets could not be priced (e.g. too few
+// providers agreed, or no provider reported a price at all). The bases that
+// did price successfully are still returned alongside this error, so callers
+// such as ExtendVoteHandler can decide whether a partial vote extension is
+// acceptable or whether to abstain entirely.
+type FailedPricesError struct {
+	Bases []string
+}
+
+func (e *FailedPricesError) Error() string {
+	return fmt.Sprintf("failed to compute oracle prices for bases: %s", strings.Join(e.Bases, ", "))
+}
+
+// computeOraclePrices computes, for every base asset reported by any
+// provider, a Time-Volume-Weighted Average Price (TVWAP) over the candles
+// collected within the last h.priceWindow (relative to h.lastPriceSyncTS).
+// If no provider reported any candles for a base within that window, it
+// falls back to a VWAP over each provider's 24h ticker price/volume. Either
+// way, a provider's contribution is discarded if its price deviates from the
+// cross-provider median by more than h.maxPriceDeviation standard
+// deviations, and the base is rejected outright if fewer than h.minProviders
+// survive that filter.
+func (h *VoteExtHandler) computeOraclePrices(providerAgg *ProviderAggregator) (map[string]sdk.Dec, error) {
+	windowEnd := h.lastPriceSyncTS
+	windowStart := windowEnd.Add(-h.priceWindow)
+
+	bases := make(map[string]struct{})
+	for _, candlesByBase := range providerAgg.providerCandles {
+		for base := range candlesByBase {
+			bases[base] = struct{}{}
+		}
+	}
+	for _, pricesByBase := range providerAgg.providerPrices {
+		for base := range pricesByBase {
+			bases[base] = struct{}{}
+		}
+	}
+
+	prices := make(map[string]sdk.Dec, len(bases))
+	var failedBases []string
+
+	for base := range bases {
+		price, err := h.computeBasePrice(providerAgg, base, windowStart, windowEnd)
+		if err != nil {
+			h.logger.Error("failed to compute oracle price", "base", base, "err", err)
+			failedBases = append(failedBases, base)
+			continue
+		}
+
+		prices[base] = price
+	}
+
+	if len(failedBases) > 0 {
+		sort.Strings(failedBases)
+		return prices, &FailedPricesError{Bases: failedBases}
+	}
+
+	return prices, nil
+}
+
+// priceCandidate is a single provider's contribution towards a base asset's
+// price, either a set of in-window candles (TVWAP path) or a ticker
+// price/volume pair (VWAP fallback path).
+type priceCandidate struct {
+	provider string
+	price    sdk.Dec // representative price, used for outlier detection
+	candles  []keepers.CandlePrice
+	volume   sdk.Dec // 24h ticker volume, only set on the VWAP fallback path
+}
+
+func (h *VoteExtHandler) computeBasePrice(
+	providerAgg *ProviderAggregator,
+	base string,
+	windowStart, windowEnd time.Time,
+) (sdk.Dec, error) {
+	var candidates []priceCandidate
+
+	for provider, candlesByBase := range providerAgg.providerCandles {
+		candles, ok := candlesByBase[base]
+		if !ok {
+			continue
+		}
+
+		inWindow := make([]keepers.CandlePrice, 0, len(candles))
+		for _, c := range candles {
+			ts := time.Unix(c.TimeStamp, 0)
+			if !ts.Before(windowStart) && !ts.After(windowEnd) {
+				inWindow = append(inWindow, c)
+			}
+		}
+		if len(inWindow) == 0 {
+			continue
+		}
+
+		// Representative price for this provider, used only to detect an
+		// outlier provider below; the actual TVWAP is computed per-candle.
+		num, denom := sdk.ZeroDec(), sdk.ZeroDec()
+		for _, c := range inWindow {
+			num = num.Add(c.Price.Mul(c.Volume))
+			denom = denom.Add(c.Volume)
+		}
+		if denom.IsZero() {
+			continue
+		}
+
+		candidates = append(candidates, priceCandidate{provider: provider, price: num.Quo(denom), candles: inWindow})
+	}
+
+	useCandles := len(candidates) > 0
+
+	if !useCandles {
+		// No provider reported any candles for this base within the window;
+		// fall back to a VWAP over 24h ticker volume.
+		for provider, pricesByBase := range providerAgg.providerPrices {
+			tp, ok := pricesByBase[base]
+			if !ok {
+				continue
+			}
+
+			candidates = append(candidates, priceCandidate{provider: provider, price: tp.Price, volume: tp.Volume})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return sdk.Dec{}, fmt.Errorf("no providers reported a price for %s", base)
+	}
+
+	survivors, err := h.rejectOutliers(base, candidates)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+
+	if len(survivors) < h.minProviders {
+		return sdk.Dec{}, fmt.Errorf("only %d/%d required providers for %s passed deviation checks", len(survivors), h.minProviders, base)
+	}
+
+	numerator, denominator := sdk.ZeroDec(), sdk.ZeroDec()
+
+	if useCandles {
+		windowDuration := sdk.NewDec(windowEnd.Sub(windowStart).Nanoseconds())
+
+		for _, c := range survivors {
+			for _, candle := range c.candles {
+				weight := sdk.NewDec(time.Unix(candle.TimeStamp, 0).Sub(windowStart).Nanoseconds()).Quo(windowDuration)
+				numerator = numerator.Add(candle.Price.Mul(candle.Volume).Mul(weight))
+				denominator = denominator.Add(candle.Volume.Mul(weight))
+			}
+		}
+	} else {
+		for _, c := range survivors {
+			numerator = numerator.Add(c.price.Mul(c.volume))
+			denominator = denominator.Add(c.volume)
+		}
+	}
+
+	if denominator.IsZero() {
+		return sdk.Dec{}, fmt.Errorf("zero total volume for %s", base)
+	}
+
+	return numerator.Quo(denominator), nil
+}
+
+// rejectOutliers discards any candidate whose representative price deviates
+// from the cross-candidate median by more than h.maxPriceDeviation standard
+// deviations. A zero h.maxPriceDeviation disables the filter.
+func (h *VoteExtHandler) rejectOutliers(base string, candidates []priceCandidate) ([]priceCandidate, error) {
+	if h.maxPriceDeviation.IsNil() || h.maxPriceDeviation.IsZero() || len(candidates) < 2 {
+		return candidates, nil
+	}
+
+	reprices := make([]sdk.Dec, len(candidates))
+	for i, c := range candidates {
+		reprices[i] = c.price
+	}
+
+	median := decMedian(reprices)
+
+	stdDev, err := decStdDev(reprices, median)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute price deviation for %s: %w", base, err)
+	}
+	if !stdDev.IsPositive() {
+		return candidates, nil
+	}
+
+	maxDeviation := stdDev.Mul(h.maxPriceDeviation)
+
+	survivors := make([]priceCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		if c.price.Sub(median).Abs().GT(maxDeviation) {
+			h.logger.Debug("rejecting outlier provider price", "provider", c.provider, "base", base, "price", c.price, "median", median)
+			continue
+		}
+
+		survivors = append(survivors, c)
+	}
+
+	return survivors, nil
+}
+
+func decMedian(ds []sdk.Dec) sdk.Dec {
+	sorted := make([]sdk.Dec, len(ds))
+	copy(sorted, ds)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].LT(sorted[j]) })
+
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+
+	return sorted[n/2-1].Add(sorted[n/2]).QuoInt64(2)
+}
+
+func decStdDev(ds []sdk.Dec, mean sdk.Dec) (sdk.Dec, error) {
+	if len(ds) < 2 {
+		return sdk.ZeroDec(), nil
+	}
+
+	sumSq := sdk.ZeroDec()
+	for _, d := range ds {
+		diff := d.Sub(mean)
+		sumSq = sumSq.Add(diff.Mul(diff))
+	}
+
+	variance := sumSq.QuoInt64(int64(len(ds)))
+	return variance.ApproxSqrt()
+}
@@ -0,0 +1,180 @@
+package abci
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/keepers"
+)
+
+func dec(s string) sdk.Dec { return sdk.MustNewDecFromStr(s) }
+
+func TestDecMedian(t *testing.T) {
+	cases := []struct {
+		name string
+		in   []sdk.Dec
+		want sdk.Dec
+	}{
+		{name: "single", in: []sdk.Dec{dec("5")}, want: dec("5")},
+		{name: "odd", in: []sdk.Dec{dec("3"), dec("1"), dec("2")}, want: dec("2")},
+		{name: "even", in: []sdk.Dec{dec("1"), dec("2"), dec("3"), dec("4")}, want: dec("2.5")},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := decMedian(tc.in); !got.Equal(tc.want) {
+				t.Errorf("decMedian(%v) = %s, want %s", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDecStdDev(t *testing.T) {
+	cases := []struct {
+		name string
+		in   []sdk.Dec
+		mean sdk.Dec
+		want sdk.Dec
+	}{
+		{name: "too few", in: []sdk.Dec{dec("5")}, mean: dec("5"), want: sdk.ZeroDec()},
+		{name: "identical values", in: []sdk.Dec{dec("5"), dec("5"), dec("5")}, mean: dec("5"), want: sdk.ZeroDec()},
+		{name: "spread", in: []sdk.Dec{dec("2"), dec("4"), dec("4"), dec("4"), dec("5"), dec("5"), dec("7"), dec("9")}, mean: dec("5"), want: dec("2")},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := decStdDev(tc.in, tc.mean)
+			if err != nil {
+				t.Fatalf("decStdDev: %v", err)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("decStdDev(%v, %s) = %s, want %s", tc.in, tc.mean, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRejectOutliers(t *testing.T) {
+	candidates := []priceCandidate{
+		{provider: "a", price: dec("10")},
+		{provider: "b", price: dec("10.1")},
+		{provider: "c", price: dec("9.9")},
+		{provider: "d", price: dec("1000")}, // way off from the rest
+	}
+
+	t.Run("filters the outlier", func(t *testing.T) {
+		h := &VoteExtHandler{logger: log.NewNopLogger(), maxPriceDeviation: dec("1")}
+
+		survivors, err := h.rejectOutliers("ATOM", candidates)
+		if err != nil {
+			t.Fatalf("rejectOutliers: %v", err)
+		}
+		if len(survivors) != 3 {
+			t.Fatalf("expected 3 survivors, got %d: %v", len(survivors), survivors)
+		}
+		for _, s := range survivors {
+			if s.provider == "d" {
+				t.Errorf("expected outlier provider d to be filtered out")
+			}
+		}
+	})
+
+	t.Run("zero deviation disables the filter", func(t *testing.T) {
+		h := &VoteExtHandler{logger: log.NewNopLogger(), maxPriceDeviation: sdk.ZeroDec()}
+
+		survivors, err := h.rejectOutliers("ATOM", candidates)
+		if err != nil {
+			t.Fatalf("rejectOutliers: %v", err)
+		}
+		if len(survivors) != len(candidates) {
+			t.Fatalf("expected no filtering, got %d survivors", len(survivors))
+		}
+	})
+
+	t.Run("fewer than two candidates disables the filter", func(t *testing.T) {
+		h := &VoteExtHandler{logger: log.NewNopLogger(), maxPriceDeviation: dec("1")}
+
+		survivors, err := h.rejectOutliers("ATOM", candidates[:1])
+		if err != nil {
+			t.Fatalf("rejectOutliers: %v", err)
+		}
+		if len(survivors) != 1 {
+			t.Fatalf("expected the single candidate to survive, got %d", len(survivors))
+		}
+	})
+}
+
+func TestComputeBasePriceVWAPFallback(t *testing.T) {
+	h := &VoteExtHandler{
+		logger:            log.NewNopLogger(),
+		priceWindow:       time.Minute,
+		minProviders:      1,
+		maxPriceDeviation: sdk.ZeroDec(),
+	}
+
+	windowEnd := time.Unix(1_700_000_000, 0)
+	windowStart := windowEnd.Add(-h.priceWindow)
+
+	providerAgg := NewProviderAggregator()
+	providerAgg.providerPrices["binance"] = map[string]keepers.TickerPrice{
+		"ATOM": {Price: dec("10"), Volume: dec("100")},
+	}
+	providerAgg.providerPrices["coinbase"] = map[string]keepers.TickerPrice{
+		"ATOM": {Price: dec("12"), Volume: dec("100")},
+	}
+
+	// No candles reported for ATOM, so computeBasePrice must fall back to the
+	// VWAP of the 24h ticker prices: (10*100 + 12*100) / (100+100) = 11.
+	price, err := h.computeBasePrice(providerAgg, "ATOM", windowStart, windowEnd)
+	if err != nil {
+		t.Fatalf("computeBasePrice: %v", err)
+	}
+	if want := dec("11"); !price.Equal(want) {
+		t.Errorf("computeBasePrice = %s, want %s", price, want)
+	}
+}
+
+func TestComputeBasePriceTVWAPFromCandles(t *testing.T) {
+	h := &VoteExtHandler{
+		logger:            log.NewNopLogger(),
+		priceWindow:       time.Minute,
+		minProviders:      1,
+		maxPriceDeviation: sdk.ZeroDec(),
+	}
+
+	windowEnd := time.Unix(1_700_000_060, 0)
+	windowStart := windowEnd.Add(-h.priceWindow)
+
+	providerAgg := NewProviderAggregator()
+	providerAgg.providerCandles["binance"] = map[string][]keepers.CandlePrice{
+		"ATOM": {
+			{Price: dec("10"), Volume: dec("100"), TimeStamp: windowStart.Unix()},
+			{Price: dec("20"), Volume: dec("100"), TimeStamp: windowEnd.Unix()},
+		},
+	}
+
+	price, err := h.computeBasePrice(providerAgg, "ATOM", windowStart, windowEnd)
+	if err != nil {
+		t.Fatalf("computeBasePrice: %v", err)
+	}
+	// Weighted towards the later (higher) candle, so above the unweighted
+	// mean (15) and at most the most recent price (20). The candle at
+	// windowStart has a period weight of zero, so here the result is exactly 20.
+	if price.LTE(dec("15")) || price.GT(dec("20")) {
+		t.Errorf("computeBasePrice = %s, want a TVWAP-weighted price in (15, 20]", price)
+	}
+}
+
+func TestComputeBasePriceNoProviders(t *testing.T) {
+	h := &VoteExtHandler{logger: log.NewNopLogger(), priceWindow: time.Minute, minProviders: 1}
+
+	windowEnd := time.Unix(1_700_000_000, 0)
+	windowStart := windowEnd.Add(-h.priceWindow)
+
+	if _, err := h.computeBasePrice(NewProviderAggregator(), "ATOM", windowStart, windowEnd); err == nil {
+		t.Fatalf("expected an error when no provider has reported a price")
+	}
+}
@@ -1,13 +1,15 @@
 package abci
 
 import (
-	"encoding/json"
 	"fmt"
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/libs/log"
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/skip-mev/vote-ext-oracle-demo/keepers"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/codec"
+	"github.com/skip-mev/vote-ext-oracle-demo/voteext"
+	oracletypes "github.com/skip-mev/vote-ext-oracle-demo/x/oracle/types"
 )
 
 type App interface {
@@ -18,19 +20,82 @@ type App interface {
 // and submit the stake-weighted prices for a given set of supported currency
 // pairs, in addition to the vote extensions used to calculate them. This is so
 // validators can verify the proposer's calculations.
-type StakeWeightedPrices struct {
-	StakeWeightedPrices map[string]sdk.Dec
-	ExtendedCommitInfo  abci.ExtendedCommitInfo
-}
+type StakeWeightedPrices = voteext.StakeWeightedPrices
 
 type ProposalHandler struct {
-	logger           log.Logger
-	app              App // this would be implemented by BaseApp when constructing the app
-	fauxOracleKeeper keepers.FauxOracleKeeper
+	logger       log.Logger
+	app          App // this would be implemented by BaseApp when constructing the app
+	oracleKeeper OracleKeeper
+	codec        codec.VoteExtensionCodec // wire format used to (de)serialize vote extensions/stake-weighted prices
+
+	// maxVoteDeviation bounds how far, relative to the finalized stake-weighted
+	// price, a validator's voted price for a base may be before PreBlocker
+	// counts that validator as having missed the vote for that block.
+	maxVoteDeviation sdk.Dec
+
+	// stakeWeightAlgorithm selects how computeStakeWeightedOraclePrices
+	// combines votes for a base asset into a finalized price.
+	stakeWeightAlgorithm oracletypes.StakeWeightAlgorithm
+
+	// medianDeviationMultiplier (K) bounds, in multiples of the median
+	// absolute deviation, how far a vote may be from the initial unweighted
+	// median before it is discarded as an outlier. Only consulted when
+	// stakeWeightAlgorithm is StakeWeightAlgorithmMedian or
+	// StakeWeightAlgorithmTrimmedMean.
+	medianDeviationMultiplier sdk.Dec
+
+	// comparePriceEpsilon bounds the acceptable relative numerical drift
+	// between a proposer's injected stake-weighted prices and a validator's
+	// own recomputation of them in ProcessProposal.
+	comparePriceEpsilon sdk.Dec
+}
+
+// NewProposalHandler returns a new ProposalHandler. If c is nil, JSONCodec is
+// used so existing callers that do not care about wire format keep the
+// historical encoding/json behavior.
+func NewProposalHandler(
+	logger log.Logger,
+	app App,
+	oracleKeeper OracleKeeper,
+	c codec.VoteExtensionCodec,
+	maxVoteDeviation sdk.Dec,
+	stakeWeightAlgorithm oracletypes.StakeWeightAlgorithm,
+	medianDeviationMultiplier sdk.Dec,
+	comparePriceEpsilon sdk.Dec,
+) *ProposalHandler {
+	if c == nil {
+		c = codec.NewJSONCodec()
+	}
+
+	return &ProposalHandler{
+		logger:                    logger,
+		app:                       app,
+		oracleKeeper:              oracleKeeper,
+		codec:                     c,
+		maxVoteDeviation:          maxVoteDeviation,
+		stakeWeightAlgorithm:      stakeWeightAlgorithm,
+		medianDeviationMultiplier: medianDeviationMultiplier,
+		comparePriceEpsilon:       comparePriceEpsilon,
+	}
+}
+
+// refreshParams re-reads the governance-managed parameters relevant to
+// stake-weighted price aggregation and verification from the oracle keeper,
+// so a SetOracleParamsProposal takes effect on the very next block instead
+// of being frozen at whatever was passed into NewProposalHandler at genesis.
+func (h *ProposalHandler) refreshParams(ctx sdk.Context) {
+	params := h.oracleKeeper.GetParams(ctx)
+
+	h.maxVoteDeviation = params.MaxVoteDeviation
+	h.stakeWeightAlgorithm = params.StakeWeightAlgorithm
+	h.medianDeviationMultiplier = params.MedianDeviationMultiplier
+	h.comparePriceEpsilon = params.ComparePriceEpsilon
 }
 
 func (h *ProposalHandler) PrepareProposal() sdk.PrepareProposalHandler {
 	return func(ctx sdk.Context, req abci.RequestPrepareProposal) abci.ResponsePrepareProposal {
+		h.refreshParams(ctx)
+
 		stakeWeightedPrices, err := h.computeStakeWeightedOraclePrices(ctx, req.LocalLastCommit)
 		if err != nil {
 			// NOTE: In SDK v0.48.x, we'd return an error here.
@@ -42,9 +107,7 @@ func (h *ProposalHandler) PrepareProposal() sdk.PrepareProposalHandler {
 			ExtendedCommitInfo:  req.LocalLastCommit,
 		}
 
-		// NOTE: We use stdlib JSON encoding, but an application may choose to use
-		// a performant mechanism. This is for demo purposes only.
-		bz, err := json.Marshal(injectedVoteExtTx)
+		bz, err := h.codec.MarshalStakeWeightedPrices(injectedVoteExtTx)
 		if err != nil {
 			h.logger.Error("failed to encode injected vote extension tx", "err", err)
 			// NOTE: In SDK v0.48.x, we'd return an error here.
@@ -67,12 +130,14 @@ func (h *ProposalHandler) PrepareProposal() sdk.PrepareProposalHandler {
 
 func (h *ProposalHandler) ProcessProposal() sdk.ProcessProposalHandler {
 	return func(ctx sdk.Context, req abci.RequestProcessProposal) abci.ResponseProcessProposal {
+		h.refreshParams(ctx)
+
 		if len(req.Txs) == 0 {
 			return abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_ACCEPT}
 		}
 
-		var injectedVoteExtTx StakeWeightedPrices
-		if err := json.Unmarshal(req.Txs[0], &injectedVoteExtTx); err != nil {
+		injectedVoteExtTx, err := h.codec.UnmarshalStakeWeightedPrices(req.Txs[0])
+		if err != nil {
 			h.logger.Error("failed to decode injected vote extension tx", "err", err)
 			return abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_REJECT}
 		}
@@ -89,13 +154,14 @@ func (h *ProposalHandler) ProcessProposal() sdk.ProcessProposalHandler {
 		if err != nil {
 			return abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_REJECT}
 		}
-		if err := compareOraclePrices(injectedVoteExtTx.StakeWeightedPrices, stakeWeightedPrices); err != nil {
+		if err := compareOraclePrices(injectedVoteExtTx.StakeWeightedPrices, stakeWeightedPrices, h.comparePriceEpsilon); err != nil {
+			h.logger.Error("proposer's stake-weighted oracle prices failed verification", "err", err)
 			return abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_REJECT}
 		}
 
 		// at this point we can persist the stake-weighted oracle prices to state
 		fCtx := h.app.GetFinalizeBlockStateCtx()
-		h.fauxOracleKeeper.SetOraclePrices(fCtx, stakeWeightedPrices)
+		h.oracleKeeper.SetOraclePrices(fCtx, stakeWeightedPrices)
 
 		// verify remainder of block proposal, i.e. req.Txs[1:]
 
@@ -104,46 +170,38 @@ func (h *ProposalHandler) ProcessProposal() sdk.ProcessProposalHandler {
 }
 
 func (h *ProposalHandler) computeStakeWeightedOraclePrices(ctx sdk.Context, ci abci.ExtendedCommitInfo) (map[string]sdk.Dec, error) {
-	requiredPairs := h.fauxOracleKeeper.GetSupportedPairs(ctx)
-	stakeWeightedPrices := make(map[string]sdk.Dec, len(requiredPairs)) // base -> average stake-weighted price
+	requiredPairs := h.oracleKeeper.GetSupportedPairs(ctx)
+	votesByBase := make(map[string][]stakeWeightedVote, len(requiredPairs))
 	for _, pair := range requiredPairs {
-		stakeWeightedPrices[pair.Base] = sdk.ZeroDec()
+		votesByBase[pair.Base] = nil
 	}
 
-	var totalStake int64
 	for _, v := range ci.Votes {
-		var voteExt OracleVoteExtension
-
-		if err := json.Unmarshal(v.VoteExtension, &voteExt); err != nil {
+		voteExt, err := h.codec.UnmarshalVoteExtension(v.VoteExtension)
+		if err != nil {
 			h.logger.Error("failed to decode vote extension", "err", err, "validator", fmt.Sprintf("%x", v.Validator.Address))
 			return nil, err
 		}
 
-		totalStake += v.Validator.Power
-
-		// Compute stake-weighted average of prices for each supported pair, i.e.
-		// (P1)(W1) + (P2)(W2) + ... + (Pn)(Wn) / (W1 + W2 + ... + Wn)
+		// Collect (price, stake) tuples per supported base asset, i.e. the
+		// inputs to aggregateStakeWeightedVotes.
 		//
 		// NOTE: These are the prices computed at the PREVIOUS height, i.e. H-1
 		for base, price := range voteExt.Prices {
-			// Only compute stake-weighted average for supported pairs.
+			// Only collect votes for supported pairs.
 			//
 			// NOTE: VerifyVoteExtension should be sufficient to ensure that only
 			// supported pairs are supplied, but we add this here for demo purposes.
-			if _, ok := stakeWeightedPrices[base]; ok {
-				stakeWeightedPrices[base] = stakeWeightedPrices[base].Add(price.MulInt64(v.Validator.Power))
+			if _, ok := votesByBase[base]; ok {
+				votesByBase[base] = append(votesByBase[base], stakeWeightedVote{price: price, stake: v.Validator.Power})
 			}
 		}
 	}
 
-	// finalize average by dividing by total stake, i.e. total weights
-	for base, price := range stakeWeightedPrices {
-		stakeWeightedPrices[base] = price.QuoInt64(totalStake)
+	stakeWeightedPrices := make(map[string]sdk.Dec, len(requiredPairs))
+	for base, votes := range votesByBase {
+		stakeWeightedPrices[base] = aggregateStakeWeightedVotes(votes, h.stakeWeightAlgorithm, h.medianDeviationMultiplier)
 	}
 
 	return stakeWeightedPrices, nil
 }
-
-func compareOraclePrices(p1, p2 map[string]sdk.Dec) error {
-	return nil
-}
@@ -0,0 +1,169 @@
+package abci
+
+import (
+	"fmt"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	oracletypes "github.com/skip-mev/vote-ext-oracle-demo/x/oracle/types"
+)
+
+// stakeWeightedVote is a single validator's voted price for a base asset,
+// paired with its voting power.
+type stakeWeightedVote struct {
+	price sdk.Dec
+	stake int64
+}
+
+// aggregateStakeWeightedVotes combines votes for a single base asset into a
+// finalized price according to algo. StakeWeightAlgorithmMedian and
+// StakeWeightAlgorithmTrimmedMean first discard votes more than k MADs from
+// the unweighted median; StakeWeightAlgorithmMean (and any unrecognized
+// value) performs no outlier rejection.
+func aggregateStakeWeightedVotes(votes []stakeWeightedVote, algo oracletypes.StakeWeightAlgorithm, k sdk.Dec) sdk.Dec {
+	if len(votes) == 0 {
+		return sdk.ZeroDec()
+	}
+
+	switch algo {
+	case oracletypes.StakeWeightAlgorithmMedian:
+		return stakeWeightedMedian(rejectVoteOutliers(votes, k))
+	case oracletypes.StakeWeightAlgorithmTrimmedMean:
+		return stakeWeightedMean(rejectVoteOutliers(votes, k))
+	default:
+		return stakeWeightedMean(votes)
+	}
+}
+
+// stakeWeightedMean computes (P1)(W1) + ... + (Pn)(Wn) / (W1 + ... + Wn).
+func stakeWeightedMean(votes []stakeWeightedVote) sdk.Dec {
+	if len(votes) == 0 {
+		return sdk.ZeroDec()
+	}
+
+	var totalStake int64
+	weightedSum := sdk.ZeroDec()
+	for _, v := range votes {
+		weightedSum = weightedSum.Add(v.price.MulInt64(v.stake))
+		totalStake += v.stake
+	}
+
+	if totalStake == 0 {
+		return sdk.ZeroDec()
+	}
+
+	return weightedSum.QuoInt64(totalStake)
+}
+
+// stakeWeightedMedian sorts votes by price and walks them in order,
+// accumulating stake until it crosses half of total stake; the vote at that
+// point is the stake-weighted median.
+func stakeWeightedMedian(votes []stakeWeightedVote) sdk.Dec {
+	if len(votes) == 0 {
+		return sdk.ZeroDec()
+	}
+
+	sorted := make([]stakeWeightedVote, len(votes))
+	copy(sorted, votes)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].price.LT(sorted[j].price) })
+
+	var totalStake int64
+	for _, v := range sorted {
+		totalStake += v.stake
+	}
+
+	if totalStake == 0 {
+		return sorted[len(sorted)-1].price
+	}
+
+	half := sdk.NewDec(totalStake).QuoInt64(2)
+
+	cumulative := sdk.ZeroDec()
+	for _, v := range sorted {
+		cumulative = cumulative.Add(sdk.NewDec(v.stake))
+		if cumulative.GTE(half) {
+			return v.price
+		}
+	}
+
+	return sorted[len(sorted)-1].price
+}
+
+// rejectVoteOutliers discards votes whose price deviates from the initial
+// unweighted median of all votes by more than k times the median absolute
+// deviation (MAD). If the MAD is zero, or there are too few votes to
+// meaningfully compute one, no votes are discarded.
+func rejectVoteOutliers(votes []stakeWeightedVote, k sdk.Dec) []stakeWeightedVote {
+	if len(votes) < 3 {
+		return votes
+	}
+
+	prices := make([]sdk.Dec, len(votes))
+	for i, v := range votes {
+		prices[i] = v.price
+	}
+
+	median := decMedian(prices)
+
+	deviations := make([]sdk.Dec, len(prices))
+	for i, p := range prices {
+		deviations[i] = p.Sub(median).Abs()
+	}
+
+	mad := decMedian(deviations)
+	if mad.IsZero() {
+		return votes
+	}
+
+	threshold := mad.Mul(k)
+
+	filtered := make([]stakeWeightedVote, 0, len(votes))
+	for _, v := range votes {
+		if v.price.Sub(median).Abs().LTE(threshold) {
+			filtered = append(filtered, v)
+		}
+	}
+
+	if len(filtered) == 0 {
+		// Every vote was flagged as an outlier relative to itself; prefer a
+		// (manipulable, but available) price over producing none at all.
+		return votes
+	}
+
+	return filtered
+}
+
+// compareOraclePrices reports whether p1 and p2 agree, for every base both
+// price, within epsilon relative tolerance. It is used by ProcessProposal to
+// bound the numerical drift that can arise between the proposer's injected
+// stake-weighted prices and a validator's own recomputation of them, e.g.
+// from floating-point-adjacent rounding differences across algorithms.
+func compareOraclePrices(p1, p2 map[string]sdk.Dec, epsilon sdk.Dec) error {
+	for base, price1 := range p1 {
+		price2, ok := p2[base]
+		if !ok {
+			return fmt.Errorf("oracle price mismatch for %s: proposer has a price, recomputation does not", base)
+		}
+
+		if price2.IsZero() {
+			if !price1.IsZero() {
+				return fmt.Errorf("oracle price mismatch for %s: proposer=%s, recomputed=%s", base, price1, price2)
+			}
+			continue
+		}
+
+		deviation := price1.Sub(price2).Abs().Quo(price2)
+		if deviation.GT(epsilon) {
+			return fmt.Errorf("oracle price mismatch for %s: proposer=%s, recomputed=%s, deviation=%s exceeds epsilon=%s", base, price1, price2, deviation, epsilon)
+		}
+	}
+
+	for base := range p2 {
+		if _, ok := p1[base]; !ok {
+			return fmt.Errorf("oracle price mismatch for %s: recomputation has a price, proposer does not", base)
+		}
+	}
+
+	return nil
+}
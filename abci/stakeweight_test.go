@@ -0,0 +1,181 @@
+package abci
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	oracletypes "github.com/skip-mev/vote-ext-oracle-demo/x/oracle/types"
+)
+
+func TestStakeWeightedMean(t *testing.T) {
+	cases := []struct {
+		name  string
+		votes []stakeWeightedVote
+		want  sdk.Dec
+	}{
+		{name: "empty", votes: nil, want: sdk.ZeroDec()},
+		{
+			name: "equal stake",
+			votes: []stakeWeightedVote{
+				{price: dec("10"), stake: 1},
+				{price: dec("20"), stake: 1},
+			},
+			want: dec("15"),
+		},
+		{
+			name: "weighted towards higher stake",
+			votes: []stakeWeightedVote{
+				{price: dec("10"), stake: 1},
+				{price: dec("20"), stake: 3},
+			},
+			want: dec("17.5"),
+		},
+		{
+			name:  "zero total stake",
+			votes: []stakeWeightedVote{{price: dec("10"), stake: 0}},
+			want:  sdk.ZeroDec(),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := stakeWeightedMean(tc.votes); !got.Equal(tc.want) {
+				t.Errorf("stakeWeightedMean(%v) = %s, want %s", tc.votes, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStakeWeightedMedian(t *testing.T) {
+	cases := []struct {
+		name  string
+		votes []stakeWeightedVote
+		want  sdk.Dec
+	}{
+		{name: "empty", votes: nil, want: sdk.ZeroDec()},
+		{
+			name: "single vote carries the whole window",
+			votes: []stakeWeightedVote{
+				{price: dec("10"), stake: 1},
+				{price: dec("20"), stake: 10},
+				{price: dec("30"), stake: 1},
+			},
+			want: dec("20"),
+		},
+		{
+			name: "cumulative stake crosses half at the middle vote",
+			votes: []stakeWeightedVote{
+				{price: dec("10"), stake: 1},
+				{price: dec("20"), stake: 1},
+				{price: dec("30"), stake: 1},
+			},
+			want: dec("20"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := stakeWeightedMedian(tc.votes); !got.Equal(tc.want) {
+				t.Errorf("stakeWeightedMedian(%v) = %s, want %s", tc.votes, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAggregateStakeWeightedVotes(t *testing.T) {
+	votes := []stakeWeightedVote{
+		{price: dec("10"), stake: 1},
+		{price: dec("10.1"), stake: 1},
+		{price: dec("9.9"), stake: 1},
+		{price: dec("1000"), stake: 1}, // outlier, should be discarded by median/trimmed-mean
+	}
+	k := dec("3")
+
+	t.Run("mean performs no outlier rejection", func(t *testing.T) {
+		got := aggregateStakeWeightedVotes(votes, oracletypes.StakeWeightAlgorithmMean, k)
+		want := stakeWeightedMean(votes)
+		if !got.Equal(want) {
+			t.Errorf("expected the plain stake-weighted mean %s, got %s", want, got)
+		}
+	})
+
+	t.Run("median discards the outlier before taking the median", func(t *testing.T) {
+		got := aggregateStakeWeightedVotes(votes, oracletypes.StakeWeightAlgorithmMedian, k)
+		if got.GT(dec("100")) {
+			t.Errorf("expected the outlier to be discarded, got %s", got)
+		}
+	})
+
+	t.Run("trimmed mean discards the outlier before averaging", func(t *testing.T) {
+		got := aggregateStakeWeightedVotes(votes, oracletypes.StakeWeightAlgorithmTrimmedMean, k)
+		if got.GT(dec("100")) {
+			t.Errorf("expected the outlier to be discarded, got %s", got)
+		}
+	})
+
+	t.Run("unrecognized algorithm falls back to mean", func(t *testing.T) {
+		got := aggregateStakeWeightedVotes(votes, oracletypes.StakeWeightAlgorithm("bogus"), k)
+		want := stakeWeightedMean(votes)
+		if !got.Equal(want) {
+			t.Errorf("expected the plain stake-weighted mean %s, got %s", want, got)
+		}
+	})
+}
+
+func TestCompareOraclePrices(t *testing.T) {
+	epsilon := dec("0.01") // 1%
+
+	cases := []struct {
+		name    string
+		p1, p2  map[string]sdk.Dec
+		wantErr bool
+	}{
+		{
+			name: "within epsilon",
+			p1:   map[string]sdk.Dec{"ATOM": dec("10")},
+			p2:   map[string]sdk.Dec{"ATOM": dec("10.05")},
+		},
+		{
+			name:    "exceeds epsilon",
+			p1:      map[string]sdk.Dec{"ATOM": dec("10")},
+			p2:      map[string]sdk.Dec{"ATOM": dec("11")},
+			wantErr: true,
+		},
+		{
+			name: "both zero",
+			p1:   map[string]sdk.Dec{"ATOM": sdk.ZeroDec()},
+			p2:   map[string]sdk.Dec{"ATOM": sdk.ZeroDec()},
+		},
+		{
+			name:    "p2 zero but p1 nonzero",
+			p1:      map[string]sdk.Dec{"ATOM": dec("10")},
+			p2:      map[string]sdk.Dec{"ATOM": sdk.ZeroDec()},
+			wantErr: true,
+		},
+		{
+			name:    "missing base in p2",
+			p1:      map[string]sdk.Dec{"ATOM": dec("10")},
+			p2:      map[string]sdk.Dec{},
+			wantErr: true,
+		},
+		{
+			name:    "extra base in p2",
+			p1:      map[string]sdk.Dec{},
+			p2:      map[string]sdk.Dec{"ATOM": dec("10")},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := compareOraclePrices(tc.p1, tc.p2, epsilon)
+			if tc.wantErr && err == nil {
+				t.Errorf("expected an error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+		})
+	}
+}
@@ -1,15 +1,19 @@
 package abci
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/cometbft/cometbft/libs/log"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/neilotoole/errgroup"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/codec"
 	"github.com/skip-mev/vote-ext-oracle-demo/keepers"
+	"github.com/skip-mev/vote-ext-oracle-demo/voteext"
 )
 
 // Provider defines an interface for fetching prices and candles for a given set
@@ -70,10 +74,7 @@ func (p *ProviderAggregator) SetProviderTickerPricesAndCandles(
 }
 
 // OracleVoteExtension defines the canonical vote extension structure.
-type OracleVoteExtension struct {
-	Height int64
-	Prices map[string]sdk.Dec
-}
+type OracleVoteExtension = voteext.OracleVoteExtension
 
 // VoteExtHandler defines a handler which implements the ExtendVote and
 // VerifyVoteExtension ABCI methods. This handler is to be instantiated and set
@@ -94,16 +95,92 @@ type VoteExtHandler struct {
 	lastPriceSyncTS time.Time                         // last time we synced prices
 	providerTimeout time.Duration                     // timeout for fetching prices from providers
 	providers       map[string]Provider               // mapping of provider name to provider (e.g. Binance -> BinanceProvider)
-	providerPairs   map[string][]keepers.CurrencyPair // mapping of provider name to supported pairs (e.g. Binance -> [ATOM/USD])
+	providerPairs   map[string][]keepers.CurrencyPair // mapping of provider name to supported pairs; refreshed every ExtendVote from OracleKeeper
 	computedPrices  map[int64]map[string]sdk.Dec      // mapping of block height to computed oracle prices (used for verification)
+	codec           codec.VoteExtensionCodec          // wire format used to (de)serialize vote extensions
+
+	priceWindow       time.Duration // how far back from lastPriceSyncTS candles are considered when computing TVWAP
+	minProviders      int           // minimum number of non-deviant providers required to accept a base's price
+	maxPriceDeviation sdk.Dec       // reject a provider's price for a base if it is more than this many stddevs from the median
+
+	// maxComputedPricesHeights bounds how many distinct heights
+	// h.computedPrices retains, evicting the oldest once exceeded, so a
+	// validator that falls behind (or never catches up) cannot grow the map
+	// without bound.
+	maxComputedPricesHeights int
+
+	OracleKeeper OracleKeeper
+}
+
+// NewVoteExtHandler returns a new VoteExtHandler. If c is nil, JSONCodec is
+// used so existing callers that do not care about wire format keep the
+// historical encoding/json behavior.
+func NewVoteExtHandler(
+	logger log.Logger,
+	providerTimeout time.Duration,
+	providers map[string]Provider,
+	oracleKeeper OracleKeeper,
+	c codec.VoteExtensionCodec,
+	priceWindow time.Duration,
+	minProviders int,
+	maxPriceDeviation sdk.Dec,
+	maxComputedPricesHeights int,
+) *VoteExtHandler {
+	if c == nil {
+		c = codec.NewJSONCodec()
+	}
 
-	FauxOracleKeeper keepers.FauxOracleKeeper
+	return &VoteExtHandler{
+		logger:                   logger,
+		providerTimeout:          providerTimeout,
+		providers:                providers,
+		computedPrices:           make(map[int64]map[string]sdk.Dec),
+		codec:                    c,
+		priceWindow:              priceWindow,
+		minProviders:             minProviders,
+		maxPriceDeviation:        maxPriceDeviation,
+		maxComputedPricesHeights: maxComputedPricesHeights,
+		OracleKeeper:             oracleKeeper,
+	}
+}
+
+// refreshParams re-reads the governance-managed parameters relevant to price
+// computation from the oracle keeper, so a SetOracleParamsProposal takes
+// effect on the very next block instead of being frozen at whatever was
+// passed into NewVoteExtHandler at genesis.
+func (h *VoteExtHandler) refreshParams(ctx sdk.Context) {
+	params := h.OracleKeeper.GetParams(ctx)
+
+	h.priceWindow = params.Window
+	h.minProviders = int(params.MinProviders)
+	h.maxPriceDeviation = params.DeviationThreshold
+}
+
+// refreshProviderPairs rebuilds h.providerPairs from the oracle keeper's
+// currently supported pairs, restricted to whitelisted providers, so that
+// governance-driven registry changes take effect on the very next block
+// instead of requiring a restart with a new static configuration.
+func (h *VoteExtHandler) refreshProviderPairs(ctx sdk.Context) {
+	supportedPairs := h.OracleKeeper.GetSupportedPairs(ctx)
+
+	providerPairs := make(map[string][]keepers.CurrencyPair, len(h.providers))
+	for providerName := range h.providers {
+		if !h.OracleKeeper.IsProviderWhitelisted(ctx, providerName) {
+			continue
+		}
+
+		providerPairs[providerName] = supportedPairs
+	}
+
+	h.providerPairs = providerPairs
 }
 
 func (h *VoteExtHandler) ExtendVoteHandler() ExtendVoteHandler {
 	return func(ctx sdk.Context, req *RequestExtendVote) (*ResponseExtendVote, error) {
 		h.currentBlock = req.Height
 		h.lastPriceSyncTS = time.Now()
+		h.refreshParams(ctx)
+		h.refreshProviderPairs(ctx)
 
 		h.logger.Info("computing oracle prices for vote extension", "height", req.Height, "time", h.lastPriceSyncTS)
 
@@ -180,11 +257,19 @@ func (h *VoteExtHandler) ExtendVoteHandler() ExtendVoteHandler {
 
 		computedPrices, err := h.computeOraclePrices(providerAgg)
 		if err != nil {
-			// NOTE: The Cosmos SDK will ensure any error returned is captured and
-			// logged. We can return nil here to indicate we do not want to produce
-			// a vote extension, and thus an empty vote extension will be provided
-			// automatically to CometBFT.
-			return nil, err
+			var failedErr *FailedPricesError
+			if !errors.As(err, &failedErr) {
+				// NOTE: The Cosmos SDK will ensure any error returned is captured and
+				// logged. We can return nil here to indicate we do not want to produce
+				// a vote extension, and thus an empty vote extension will be provided
+				// automatically to CometBFT.
+				return nil, err
+			}
+
+			// Some bases failed to price. We still have computedPrices for the
+			// bases that succeeded; whether that's enough to produce a vote
+			// extension is decided below, per required base.
+			h.logger.Error("failed to compute oracle prices for some bases", "bases", failedErr.Bases)
 		}
 
 		for base := range requiredRates {
@@ -204,16 +289,19 @@ func (h *VoteExtHandler) ExtendVoteHandler() ExtendVoteHandler {
 			Prices: computedPrices,
 		}
 
-		// NOTE: We use stdlib JSON encoding, but an application may choose to use
-		// a performant mechanism. This is for demo purposes only.
-		bz, err := json.Marshal(voteExt)
+		bz, err := h.codec.MarshalVoteExtension(voteExt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal vote extension: %w", err)
 		}
 
-		// TODO/XXX: A real application would likely want to persist these prices
-		// and ensure they're pruned when no longer needed.
+		// The canonical, persisted history of finalized prices lives in the
+		// oracle keeper (see Keeper.SetOraclePrices/PruneHistory);
+		// h.computedPrices only needs to retain enough recent heights for
+		// VerifyVoteExtensionHandler to sanity-check incoming votes against,
+		// so cap it rather than letting it grow unbounded under long
+		// validator downtime.
 		h.computedPrices[req.Height] = computedPrices
+		h.evictOldComputedPrices()
 
 		return &ResponseExtendVote{VoteExtension: bz}, nil
 	}
@@ -221,9 +309,7 @@ func (h *VoteExtHandler) ExtendVoteHandler() ExtendVoteHandler {
 
 func (h *VoteExtHandler) VerifyVoteExtensionHandler() VerifyVoteExtensionHandler {
 	return func(ctx sdk.Context, req *RequestVerifyVoteExtension) (*ResponseVerifyVoteExtension, error) {
-		var voteExt OracleVoteExtension
-
-		err := json.Unmarshal(req.VoteExtension, &voteExt)
+		voteExt, err := h.codec.UnmarshalVoteExtension(req.VoteExtension)
 		if err != nil {
 			// NOTE: It is safe to return an error as the Cosmos SDK will capture all
 			// errors, log them, and reject the proposal.
@@ -242,10 +328,24 @@ func (h *VoteExtHandler) VerifyVoteExtensionHandler() VerifyVoteExtensionHandler
 	}
 }
 
-func (h *VoteExtHandler) computeOraclePrices(providerAgg *ProviderAggregator) (prices map[string]sdk.Dec, err error) {
-	// Compute TVWAP based on candles or VWAP based on prices. For brevity and
-	// demo purposes, we omit implementation.
-	return prices, err
+// evictOldComputedPrices discards the oldest entries in h.computedPrices
+// once it holds more than h.maxComputedPricesHeights distinct heights. A
+// maxComputedPricesHeights of zero or less disables the cap.
+func (h *VoteExtHandler) evictOldComputedPrices() {
+	if h.maxComputedPricesHeights <= 0 || len(h.computedPrices) <= h.maxComputedPricesHeights {
+		return
+	}
+
+	heights := make([]int64, 0, len(h.computedPrices))
+	for height := range h.computedPrices {
+		heights = append(heights, height)
+	}
+
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+
+	for _, height := range heights[:len(heights)-h.maxComputedPricesHeights] {
+		delete(h.computedPrices, height)
+	}
 }
 
 func (h *VoteExtHandler) verifyPrices(ctx sdk.Context, prices map[string]sdk.Dec) error {
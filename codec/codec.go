@@ -0,0 +1,25 @@
+// Package codec provides pluggable (de)serialization strategies for the
+// OracleVoteExtension and StakeWeightedPrices structures that are gossiped in
+// CometBFT vote extensions and injected proposal txs. encoding/json, while
+// simple, is explicitly non-performant and bloats vote payloads, so operators
+// can instead opt into a compact binary layout, a protobuf layout, or wrap any
+// of the above in a compression layer.
+package codec
+
+import "github.com/skip-mev/vote-ext-oracle-demo/voteext"
+
+// VoteExtensionCodec defines the interface that must be implemented by any
+// wire format used to (de)serialize vote extensions and proposer-injected
+// stake-weighted prices. Implementations MUST be deterministic for a given
+// input so that VerifyVoteExtension and ProcessProposal can reproduce the
+// same bytes a peer produced.
+type VoteExtensionCodec interface {
+	// Name identifies the codec, primarily for logging and metrics.
+	Name() string
+
+	MarshalVoteExtension(voteext.OracleVoteExtension) ([]byte, error)
+	UnmarshalVoteExtension([]byte) (voteext.OracleVoteExtension, error)
+
+	MarshalStakeWeightedPrices(voteext.StakeWeightedPrices) ([]byte, error)
+	UnmarshalStakeWeightedPrices([]byte) (voteext.StakeWeightedPrices, error)
+}
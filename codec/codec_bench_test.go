@@ -0,0 +1,74 @@
+package codec_test
+
+import (
+	"fmt"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/codec"
+	"github.com/skip-mev/vote-ext-oracle-demo/voteext"
+)
+
+// pairCounts defines the N currency-pair fan-outs we benchmark each codec
+// against, loosely mirroring realistic deployments (a handful of majors, up to
+// a long tail of exotic pairs).
+var pairCounts = []int{1, 10, 50, 200}
+
+func voteExtensionOfSize(n int) voteext.OracleVoteExtension {
+	prices := make(map[string]sdk.Dec, n)
+	for i := 0; i < n; i++ {
+		prices[fmt.Sprintf("ASSET%d", i)] = sdk.MustNewDecFromStr("12345.678901234567890123")
+	}
+
+	return voteext.OracleVoteExtension{Height: 100, Prices: prices}
+}
+
+func allCodecs(b *testing.B) []codec.VoteExtensionCodec {
+	zstdCodec, err := codec.NewCompressedCodec(codec.NewProtoCodec(), codec.CompressionZstd)
+	if err != nil {
+		b.Fatalf("failed to build zstd codec: %v", err)
+	}
+
+	snappyCodec, err := codec.NewCompressedCodec(codec.NewCompactCodec(), codec.CompressionSnappy)
+	if err != nil {
+		b.Fatalf("failed to build snappy codec: %v", err)
+	}
+
+	return []codec.VoteExtensionCodec{
+		codec.NewJSONCodec(),
+		codec.NewCompactCodec(),
+		codec.NewProtoCodec(),
+		zstdCodec,
+		snappyCodec,
+	}
+}
+
+// BenchmarkMarshalVoteExtension reports payload size (via b.ReportMetric) and
+// CPU cost of marshaling a vote extension with N currency pairs for every
+// registered codec, so operators can pick the size/CPU tradeoff that suits
+// their CometBFT gossip bandwidth budget.
+func BenchmarkMarshalVoteExtension(b *testing.B) {
+	for _, n := range pairCounts {
+		ve := voteExtensionOfSize(n)
+
+		for _, c := range allCodecs(b) {
+			c := c
+			b.Run(fmt.Sprintf("%s/pairs=%d", c.Name(), n), func(b *testing.B) {
+				var sz int
+
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					bz, err := c.MarshalVoteExtension(ve)
+					if err != nil {
+						b.Fatalf("marshal failed: %v", err)
+					}
+					sz = len(bz)
+				}
+
+				b.ReportMetric(float64(sz), "bytes/op")
+			})
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"cosmossdk.io/math"
+	abci "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/voteext"
+)
+
+// CompactCodec is a VoteExtensionCodec that uses a fixed, hand-rolled binary
+// layout instead of a self-describing format. A vote extension is encoded as
+// varint(height) followed by its price map, and each price map is encoded as:
+//
+//	varint(numPrices)
+//	for each (base, price), with bases sorted lexicographically for determinism:
+//	  varint(len(base)) || base bytes
+//	  varint(len(price.Marshal())) || price.Marshal() bytes (sdk.Dec's own binary repr)
+//
+// Stake-weighted prices are encoded as their price map (with no height)
+// followed by varint(len) || the ExtendedCommitInfo's own Marshal bytes.
+//
+// Sorting the base symbols is what makes the encoding deterministic across
+// validators, which matters because VerifyVoteExtension and ProcessProposal
+// must be able to reproduce identical bytes from identical inputs.
+type CompactCodec struct{}
+
+// NewCompactCodec returns a new CompactCodec.
+func NewCompactCodec() *CompactCodec {
+	return &CompactCodec{}
+}
+
+func (c *CompactCodec) Name() string { return "compact" }
+
+func (c *CompactCodec) MarshalVoteExtension(ve voteext.OracleVoteExtension) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := writeVarint(&buf, ve.Height); err != nil {
+		return nil, err
+	}
+	if err := writePriceMap(&buf, ve.Prices); err != nil {
+		return nil, fmt.Errorf("failed to encode vote extension prices: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (c *CompactCodec) UnmarshalVoteExtension(bz []byte) (voteext.OracleVoteExtension, error) {
+	buf := bytes.NewReader(bz)
+
+	height, err := readVarint(buf)
+	if err != nil {
+		return voteext.OracleVoteExtension{}, fmt.Errorf("failed to decode vote extension height: %w", err)
+	}
+
+	prices, err := readPriceMap(buf)
+	if err != nil {
+		return voteext.OracleVoteExtension{}, fmt.Errorf("failed to decode vote extension prices: %w", err)
+	}
+
+	return voteext.OracleVoteExtension{Height: height, Prices: prices}, nil
+}
+
+func (c *CompactCodec) MarshalStakeWeightedPrices(swp voteext.StakeWeightedPrices) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := writePriceMap(&buf, swp.StakeWeightedPrices); err != nil {
+		return nil, fmt.Errorf("failed to encode stake-weighted prices: %w", err)
+	}
+
+	// The ExtendedCommitInfo is already a proto.Message generated by CometBFT,
+	// so we defer to its own Marshal rather than reinventing a layout for it.
+	ciBz, err := swp.ExtendedCommitInfo.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode extended commit info: %w", err)
+	}
+	if err := writeBytes(&buf, ciBz); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (c *CompactCodec) UnmarshalStakeWeightedPrices(bz []byte) (voteext.StakeWeightedPrices, error) {
+	buf := bytes.NewReader(bz)
+
+	prices, err := readPriceMap(buf)
+	if err != nil {
+		return voteext.StakeWeightedPrices{}, fmt.Errorf("failed to decode stake-weighted prices: %w", err)
+	}
+
+	ciBz, err := readBytes(buf)
+	if err != nil {
+		return voteext.StakeWeightedPrices{}, fmt.Errorf("failed to decode extended commit info: %w", err)
+	}
+
+	var ci abci.ExtendedCommitInfo
+	if err := ci.Unmarshal(ciBz); err != nil {
+		return voteext.StakeWeightedPrices{}, fmt.Errorf("failed to unmarshal extended commit info: %w", err)
+	}
+
+	return voteext.StakeWeightedPrices{StakeWeightedPrices: prices, ExtendedCommitInfo: ci}, nil
+}
+
+func writePriceMap(buf *bytes.Buffer, prices map[string]sdk.Dec) error {
+	bases := make([]string, 0, len(prices))
+	for base := range prices {
+		bases = append(bases, base)
+	}
+	sort.Strings(bases)
+
+	if err := writeVarint(buf, int64(len(bases))); err != nil {
+		return err
+	}
+
+	for _, base := range bases {
+		if err := writeString(buf, base); err != nil {
+			return err
+		}
+
+		decBz, err := prices[base].Marshal()
+		if err != nil {
+			return fmt.Errorf("failed to marshal price for %s: %w", base, err)
+		}
+		if err := writeBytes(buf, decBz); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readPriceMap(buf *bytes.Reader) (map[string]sdk.Dec, error) {
+	n, err := readVarint(buf)
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 || n > int64(buf.Len()) {
+		return nil, fmt.Errorf("invalid price map length %d exceeds remaining buffer of %d bytes", n, buf.Len())
+	}
+
+	prices := make(map[string]sdk.Dec, n)
+	for i := int64(0); i < n; i++ {
+		base, err := readString(buf)
+		if err != nil {
+			return nil, err
+		}
+
+		decBz, err := readBytes(buf)
+		if err != nil {
+			return nil, err
+		}
+
+		var dec math.LegacyDec
+		if err := dec.Unmarshal(decBz); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal price for %s: %w", base, err)
+		}
+
+		prices[base] = dec
+	}
+
+	return prices, nil
+}
+
+func writeVarint(buf *bytes.Buffer, v int64) error {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(tmp[:], v)
+	_, err := buf.Write(tmp[:n])
+	return err
+}
+
+func readVarint(buf *bytes.Reader) (int64, error) {
+	v, err := binary.ReadVarint(buf)
+	if err != nil {
+		return 0, err
+	}
+	return v, nil
+}
+
+func writeBytes(buf *bytes.Buffer, bz []byte) error {
+	if err := writeVarint(buf, int64(len(bz))); err != nil {
+		return err
+	}
+	_, err := buf.Write(bz)
+	return err
+}
+
+func readBytes(buf *bytes.Reader) ([]byte, error) {
+	n, err := readVarint(buf)
+	if err != nil {
+		return nil, err
+	}
+	// n comes straight off the wire, so bound it against what's actually left
+	// in buf before allocating: otherwise a negative or oversized varint
+	// either panics make() or attempts a multi-GB allocation.
+	if n < 0 || n > int64(buf.Len()) {
+		return nil, fmt.Errorf("invalid byte length %d exceeds remaining buffer of %d bytes", n, buf.Len())
+	}
+
+	bz := make([]byte, n)
+	if _, err := buf.Read(bz); err != nil {
+		return nil, err
+	}
+
+	return bz, nil
+}
+
+func writeString(buf *bytes.Buffer, s string) error {
+	return writeBytes(buf, []byte(s))
+}
+
+func readString(buf *bytes.Reader) (string, error) {
+	bz, err := readBytes(buf)
+	if err != nil {
+		return "", err
+	}
+	return string(bz), nil
+}
@@ -0,0 +1,115 @@
+package codec
+
+import (
+	"fmt"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/voteext"
+)
+
+// CompressionAlgo identifies the compression scheme used by CompressedCodec.
+type CompressionAlgo string
+
+const (
+	CompressionZstd   CompressionAlgo = "zstd"
+	CompressionSnappy CompressionAlgo = "snappy"
+)
+
+// CompressedCodec wraps any VoteExtensionCodec and compresses its output with
+// the configured algorithm. Vote extensions are small and numerous, so the
+// best tradeoff between size and CPU is workload dependent: snappy is
+// essentially free but yields modest savings, while zstd spends more CPU for
+// a meaningfully smaller payload. Operators pick whichever their CometBFT
+// mempool/gossip bandwidth budget favors.
+type CompressedCodec struct {
+	inner VoteExtensionCodec
+	algo  CompressionAlgo
+
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+}
+
+// NewCompressedCodec wraps inner with the given compression algorithm.
+func NewCompressedCodec(inner VoteExtensionCodec, algo CompressionAlgo) (*CompressedCodec, error) {
+	c := &CompressedCodec{inner: inner, algo: algo}
+
+	if algo == CompressionZstd {
+		enc, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zstd encoder: %w", err)
+		}
+		dec, err := zstd.NewReader(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zstd decoder: %w", err)
+		}
+
+		c.zstdEncoder = enc
+		c.zstdDecoder = dec
+	}
+
+	return c, nil
+}
+
+func (c *CompressedCodec) Name() string {
+	return fmt.Sprintf("%s+%s", c.inner.Name(), c.algo)
+}
+
+func (c *CompressedCodec) compress(bz []byte) ([]byte, error) {
+	switch c.algo {
+	case CompressionZstd:
+		return c.zstdEncoder.EncodeAll(bz, nil), nil
+
+	case CompressionSnappy:
+		return snappy.Encode(nil, bz), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported compression algorithm: %s", c.algo)
+	}
+}
+
+func (c *CompressedCodec) decompress(bz []byte) ([]byte, error) {
+	switch c.algo {
+	case CompressionZstd:
+		return c.zstdDecoder.DecodeAll(bz, nil)
+
+	case CompressionSnappy:
+		return snappy.Decode(nil, bz)
+
+	default:
+		return nil, fmt.Errorf("unsupported compression algorithm: %s", c.algo)
+	}
+}
+
+func (c *CompressedCodec) MarshalVoteExtension(ve voteext.OracleVoteExtension) ([]byte, error) {
+	bz, err := c.inner.MarshalVoteExtension(ve)
+	if err != nil {
+		return nil, err
+	}
+	return c.compress(bz)
+}
+
+func (c *CompressedCodec) UnmarshalVoteExtension(bz []byte) (voteext.OracleVoteExtension, error) {
+	raw, err := c.decompress(bz)
+	if err != nil {
+		return voteext.OracleVoteExtension{}, fmt.Errorf("failed to decompress vote extension: %w", err)
+	}
+	return c.inner.UnmarshalVoteExtension(raw)
+}
+
+func (c *CompressedCodec) MarshalStakeWeightedPrices(swp voteext.StakeWeightedPrices) ([]byte, error) {
+	bz, err := c.inner.MarshalStakeWeightedPrices(swp)
+	if err != nil {
+		return nil, err
+	}
+	return c.compress(bz)
+}
+
+func (c *CompressedCodec) UnmarshalStakeWeightedPrices(bz []byte) (voteext.StakeWeightedPrices, error) {
+	raw, err := c.decompress(bz)
+	if err != nil {
+		return voteext.StakeWeightedPrices{}, fmt.Errorf("failed to decompress stake-weighted prices: %w", err)
+	}
+	return c.inner.UnmarshalStakeWeightedPrices(raw)
+}
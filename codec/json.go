@@ -0,0 +1,58 @@
+package codec
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/voteext"
+)
+
+// JSONCodec is a VoteExtensionCodec backed by encoding/json. It is the
+// simplest codec to reason about and is kept as the default so existing
+// deployments behave exactly as before, but it is the least performant and
+// most verbose of the available codecs -- prefer CompactCodec or ProtoCodec
+// (optionally wrapped in a CompressedCodec) for production use.
+type JSONCodec struct{}
+
+// NewJSONCodec returns a new JSONCodec.
+func NewJSONCodec() *JSONCodec {
+	return &JSONCodec{}
+}
+
+func (c *JSONCodec) Name() string { return "json" }
+
+func (c *JSONCodec) MarshalVoteExtension(ve voteext.OracleVoteExtension) ([]byte, error) {
+	bz, err := json.Marshal(ve)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal vote extension: %w", err)
+	}
+
+	return bz, nil
+}
+
+func (c *JSONCodec) UnmarshalVoteExtension(bz []byte) (voteext.OracleVoteExtension, error) {
+	var ve voteext.OracleVoteExtension
+	if err := json.Unmarshal(bz, &ve); err != nil {
+		return voteext.OracleVoteExtension{}, fmt.Errorf("failed to unmarshal vote extension: %w", err)
+	}
+
+	return ve, nil
+}
+
+func (c *JSONCodec) MarshalStakeWeightedPrices(swp voteext.StakeWeightedPrices) ([]byte, error) {
+	bz, err := json.Marshal(swp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal stake-weighted prices: %w", err)
+	}
+
+	return bz, nil
+}
+
+func (c *JSONCodec) UnmarshalStakeWeightedPrices(bz []byte) (voteext.StakeWeightedPrices, error) {
+	var swp voteext.StakeWeightedPrices
+	if err := json.Unmarshal(bz, &swp); err != nil {
+		return voteext.StakeWeightedPrices{}, fmt.Errorf("failed to unmarshal stake-weighted prices: %w", err)
+	}
+
+	return swp, nil
+}
@@ -49,3 +49,20 @@ func (k FauxOracleKeeper) SetOraclePrices(_ sdk.Context, prices map[string]math.
 	k.prices = prices
 	return nil
 }
+
+// IsProviderWhitelisted always returns true, since FauxOracleKeeper predates
+// the governance-managed provider whitelist and has no notion of rejecting a
+// provider.
+func (k FauxOracleKeeper) IsProviderWhitelisted(_ sdk.Context, _ string) bool {
+	return true
+}
+
+// HandleValidatorVote is a no-op, since FauxOracleKeeper predates
+// participation tracking and slashing and has no notion of a miss window.
+func (k FauxOracleKeeper) HandleValidatorVote(_ sdk.Context, _ sdk.ConsAddress, _ int64, _ bool) error {
+	return nil
+}
+
+// PruneHistory is a no-op, since FauxOracleKeeper predates price history
+// persistence and keeps no price history to prune.
+func (k FauxOracleKeeper) PruneHistory(_ sdk.Context, _ string) {}
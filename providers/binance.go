@@ -0,0 +1,85 @@
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/keepers"
+)
+
+// NewBinanceProvider returns a StreamingProvider subscribed to Binance's
+// combined-stream 24hr ticker feed.
+func NewBinanceProvider(logger log.Logger) *BaseStreamProvider {
+	return newBaseStreamProvider(logger, "binance", &binanceHandler{}).start()
+}
+
+type binanceHandler struct{}
+
+func (h *binanceHandler) endpoint() string {
+	return "wss://stream.binance.com:9443/ws"
+}
+
+func (h *binanceHandler) symbolForPair(pair keepers.CurrencyPair) string {
+	return strings.ToUpper(pair.Base + pair.Quote)
+}
+
+func (h *binanceHandler) subscribeMessages(pairs []keepers.CurrencyPair) ([][]byte, error) {
+	streams := make([]string, len(pairs))
+	for i, pair := range pairs {
+		streams[i] = strings.ToLower(pair.Base+pair.Quote) + "@ticker"
+	}
+
+	msg, err := json.Marshal(map[string]any{
+		"method": "SUBSCRIBE",
+		"params": streams,
+		"id":     1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("binance: failed to encode subscribe message: %w", err)
+	}
+
+	return [][]byte{msg}, nil
+}
+
+func (h *binanceHandler) handleMessage(data []byte, p *BaseStreamProvider) error {
+	var msg struct {
+		Event  string `json:"e"`
+		Symbol string `json:"s"`
+		Close  string `json:"c"`
+		Volume string `json:"v"`
+	}
+
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return fmt.Errorf("binance: failed to decode message: %w", err)
+	}
+
+	if msg.Event != "24hrTicker" || msg.Symbol == "" {
+		// Not a ticker frame, e.g. a subscription ack; nothing to record.
+		return nil
+	}
+
+	base, ok := p.baseForSymbol(msg.Symbol)
+	if !ok {
+		return nil
+	}
+
+	price, err := sdk.NewDecFromStr(msg.Close)
+	if err != nil {
+		return fmt.Errorf("binance: failed to parse price %q: %w", msg.Close, err)
+	}
+
+	volume, err := sdk.NewDecFromStr(msg.Volume)
+	if err != nil {
+		return fmt.Errorf("binance: failed to parse volume %q: %w", msg.Volume, err)
+	}
+
+	p.recordTick(base, keepers.TickerPrice{Price: price, Volume: volume})
+	p.recordCandle(base, keepers.CandlePrice{Price: price, Volume: volume, TimeStamp: time.Now().UnixMilli()})
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/keepers"
+)
+
+// NewCoinbaseProvider returns a StreamingProvider subscribed to Coinbase
+// Exchange's "ticker" channel.
+func NewCoinbaseProvider(logger log.Logger) *BaseStreamProvider {
+	return newBaseStreamProvider(logger, "coinbase", &coinbaseHandler{}).start()
+}
+
+type coinbaseHandler struct{}
+
+func (h *coinbaseHandler) endpoint() string {
+	return "wss://ws-feed.exchange.coinbase.com"
+}
+
+func (h *coinbaseHandler) symbolForPair(pair keepers.CurrencyPair) string {
+	return pair.Base + "-" + pair.Quote
+}
+
+func (h *coinbaseHandler) subscribeMessages(pairs []keepers.CurrencyPair) ([][]byte, error) {
+	productIDs := make([]string, len(pairs))
+	for i, pair := range pairs {
+		productIDs[i] = h.symbolForPair(pair)
+	}
+
+	msg, err := json.Marshal(map[string]any{
+		"type":        "subscribe",
+		"product_ids": productIDs,
+		"channels":    []string{"ticker"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("coinbase: failed to encode subscribe message: %w", err)
+	}
+
+	return [][]byte{msg}, nil
+}
+
+func (h *coinbaseHandler) handleMessage(data []byte, p *BaseStreamProvider) error {
+	var msg struct {
+		Type      string `json:"type"`
+		ProductID string `json:"product_id"`
+		Price     string `json:"price"`
+		Volume24h string `json:"volume_24h"`
+	}
+
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return fmt.Errorf("coinbase: failed to decode message: %w", err)
+	}
+
+	if msg.Type != "ticker" || msg.ProductID == "" {
+		return nil
+	}
+
+	base, ok := p.baseForSymbol(msg.ProductID)
+	if !ok {
+		return nil
+	}
+
+	price, err := sdk.NewDecFromStr(msg.Price)
+	if err != nil {
+		return fmt.Errorf("coinbase: failed to parse price %q: %w", msg.Price, err)
+	}
+
+	volume, err := sdk.NewDecFromStr(msg.Volume24h)
+	if err != nil {
+		return fmt.Errorf("coinbase: failed to parse volume %q: %w", msg.Volume24h, err)
+	}
+
+	p.recordTick(base, keepers.TickerPrice{Price: price, Volume: volume})
+	p.recordCandle(base, keepers.CandlePrice{Price: price, Volume: volume, TimeStamp: time.Now().UnixMilli()})
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package providers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/keepers"
+)
+
+// HTTPProvider is the pull-based price source used as a fallback when a
+// StreamingProvider has no fresh cached data for a base. Any existing
+// HTTP-polling abci.Provider implementation satisfies this interface.
+type HTTPProvider interface {
+	GetTickerPrices(...keepers.CurrencyPair) (map[string]keepers.TickerPrice, error)
+	GetCandlePrices(...keepers.CurrencyPair) (map[string][]keepers.CandlePrice, error)
+}
+
+// CompositeProvider merges a StreamingProvider with an HTTP-polling
+// fallback. It prefers the streaming provider's cached prices, falling back
+// to the HTTP provider only for bases the stream hasn't produced a tick for
+// within maxStaleness (including bases it was never subscribed to).
+type CompositeProvider struct {
+	logger       log.Logger
+	streaming    StreamingProvider
+	fallback     HTTPProvider
+	maxStaleness time.Duration
+}
+
+// NewCompositeProvider returns a Provider that reads from streaming whenever
+// it has data no older than maxStaleness, and falls back to fallback
+// otherwise.
+func NewCompositeProvider(logger log.Logger, streaming StreamingProvider, fallback HTTPProvider, maxStaleness time.Duration) *CompositeProvider {
+	return &CompositeProvider{
+		logger:       logger,
+		streaming:    streaming,
+		fallback:     fallback,
+		maxStaleness: maxStaleness,
+	}
+}
+
+func (p *CompositeProvider) GetTickerPrices(pairs ...keepers.CurrencyPair) (map[string]keepers.TickerPrice, error) {
+	fresh, stale := p.partitionByFreshness(pairs)
+
+	out := make(map[string]keepers.TickerPrice, len(pairs))
+
+	if len(fresh) > 0 {
+		streamed, err := p.streaming.GetTickerPrices(fresh...)
+		if err != nil {
+			p.logger.Error("streaming provider failed to return cached ticker prices", "err", err)
+		}
+		for k, v := range streamed {
+			out[k] = v
+		}
+	}
+
+	if len(stale) > 0 {
+		fellBack, err := p.fallback.GetTickerPrices(stale...)
+		if err != nil {
+			p.logger.Error("fallback provider failed to fetch ticker prices", "err", err)
+		}
+		for k, v := range fellBack {
+			out[k] = v
+		}
+	}
+
+	if len(out) == 0 {
+		return nil, fmt.Errorf("composite provider: no ticker prices available from streaming or fallback sources")
+	}
+
+	return out, nil
+}
+
+func (p *CompositeProvider) GetCandlePrices(pairs ...keepers.CurrencyPair) (map[string][]keepers.CandlePrice, error) {
+	fresh, stale := p.partitionByFreshness(pairs)
+
+	out := make(map[string][]keepers.CandlePrice, len(pairs))
+
+	if len(fresh) > 0 {
+		streamed, err := p.streaming.GetCandlePrices(fresh...)
+		if err != nil {
+			p.logger.Error("streaming provider failed to return cached candles", "err", err)
+		}
+		for k, v := range streamed {
+			out[k] = v
+		}
+	}
+
+	if len(stale) > 0 {
+		fellBack, err := p.fallback.GetCandlePrices(stale...)
+		if err != nil {
+			p.logger.Error("fallback provider failed to fetch candles", "err", err)
+		}
+		for k, v := range fellBack {
+			out[k] = v
+		}
+	}
+
+	if len(out) == 0 {
+		return nil, fmt.Errorf("composite provider: no candles available from streaming or fallback sources")
+	}
+
+	return out, nil
+}
+
+// partitionByFreshness splits pairs into those the streaming provider has
+// updated within maxStaleness and those it hasn't (or never subscribed to).
+func (p *CompositeProvider) partitionByFreshness(pairs []keepers.CurrencyPair) (fresh, stale []keepers.CurrencyPair) {
+	now := time.Now()
+
+	for _, pair := range pairs {
+		updatedAt, ok := p.streaming.LastUpdated(pair.Base)
+		if ok && now.Sub(updatedAt) <= p.maxStaleness {
+			fresh = append(fresh, pair)
+		} else {
+			stale = append(stale, pair)
+		}
+	}
+
+	return fresh, stale
+}
@@ -0,0 +1,198 @@
+package providers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/keepers"
+)
+
+// TestWsHandlerConformance exercises each exchange's wsHandler against a
+// single sample ticker frame, without dialing any real connection:
+// newBaseStreamProvider never starts the background run() loop unless
+// start() is called explicitly, which none of these constructors here do.
+func TestWsHandlerConformance(t *testing.T) {
+	pair := keepers.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+	wantPrice := sdk.MustNewDecFromStr("11.98")
+
+	cases := []struct {
+		name    string
+		handler wsHandler
+		frame   []byte
+	}{
+		{
+			name:    "binance",
+			handler: &binanceHandler{},
+			frame:   []byte(`{"e":"24hrTicker","s":"ATOMUSDT","c":"11.98","v":"24000"}`),
+		},
+		{
+			name:    "coinbase",
+			handler: &coinbaseHandler{},
+			frame:   []byte(`{"type":"ticker","product_id":"ATOM-USDT","price":"11.98","volume_24h":"24000"}`),
+		},
+		{
+			name:    "kraken",
+			handler: &krakenHandler{},
+			frame:   []byte(`[336,{"c":["11.98","10"],"v":["1000","24000"]},"ticker","ATOM/USDT"]`),
+		},
+		{
+			name:    "huobi",
+			handler: &huobiHandler{},
+			frame:   gzipJSON(t, `{"ch":"market.atomusdt.ticker","tick":{"close":11.98,"vol":24000}}`),
+		},
+		{
+			name:    "okx",
+			handler: &okxHandler{},
+			frame:   []byte(`{"arg":{"channel":"tickers","instId":"ATOM-USDT"},"data":[{"last":"11.98","vol24h":"24000"}]}`),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if endpoint := tc.handler.endpoint(); endpoint == "" {
+				t.Fatalf("endpoint() returned an empty string")
+			}
+
+			msgs, err := tc.handler.subscribeMessages([]keepers.CurrencyPair{pair})
+			if err != nil {
+				t.Fatalf("subscribeMessages: %v", err)
+			}
+			if len(msgs) == 0 {
+				t.Fatalf("subscribeMessages returned no messages")
+			}
+
+			p := newBaseStreamProvider(log.NewNopLogger(), tc.name, tc.handler)
+			defer p.Stop()
+
+			// Subscribe registers the symbol->base mapping handleMessage
+			// needs to attribute the frame to pair.Base.
+			if err := p.Subscribe(pair); err != nil {
+				t.Fatalf("Subscribe: %v", err)
+			}
+
+			if err := tc.handler.handleMessage(tc.frame, p); err != nil {
+				t.Fatalf("handleMessage: %v", err)
+			}
+
+			prices, err := p.GetTickerPrices(pair)
+			if err != nil {
+				t.Fatalf("GetTickerPrices: %v", err)
+			}
+
+			tp, ok := prices[pair.String()]
+			if !ok {
+				t.Fatalf("expected a cached tick keyed by %q, got %v", pair.String(), prices)
+			}
+			if !tp.Price.Equal(wantPrice) {
+				t.Errorf("expected price %s, got %s", wantPrice, tp.Price)
+			}
+
+			if _, ok := p.LastUpdated(pair.Base); !ok {
+				t.Errorf("expected LastUpdated to report a timestamp after handleMessage")
+			}
+		})
+	}
+}
+
+func TestCompositeProviderFallsBackWhenStale(t *testing.T) {
+	pair := keepers.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+
+	streaming := newBaseStreamProvider(log.NewNopLogger(), "fake-stream", &binanceHandler{})
+	defer streaming.Stop()
+
+	fallback := &stubHTTPProvider{
+		tickers: map[string]keepers.TickerPrice{
+			pair.String(): {Price: sdk.MustNewDecFromStr("12.00"), Volume: sdk.MustNewDecFromStr("1")},
+		},
+	}
+
+	composite := NewCompositeProvider(log.NewNopLogger(), streaming, fallback, 0)
+
+	prices, err := composite.GetTickerPrices(pair)
+	if err != nil {
+		t.Fatalf("GetTickerPrices: %v", err)
+	}
+
+	tp, ok := prices[pair.String()]
+	if !ok || !tp.Price.Equal(sdk.MustNewDecFromStr("12.00")) {
+		t.Fatalf("expected fallback price 12.00, got %v", prices)
+	}
+}
+
+func TestCompositeProviderPrefersFreshStream(t *testing.T) {
+	pair := keepers.CurrencyPair{Base: "ATOM", Quote: "USDT"}
+
+	streaming := newBaseStreamProvider(log.NewNopLogger(), "fake-stream", &binanceHandler{})
+	defer streaming.Stop()
+
+	if err := streaming.Subscribe(pair); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	streaming.recordTick(pair.Base, keepers.TickerPrice{
+		Price:  sdk.MustNewDecFromStr("11.98"),
+		Volume: sdk.MustNewDecFromStr("1"),
+	})
+
+	fallback := &stubHTTPProvider{
+		tickers: map[string]keepers.TickerPrice{
+			pair.String(): {Price: sdk.MustNewDecFromStr("999"), Volume: sdk.MustNewDecFromStr("1")},
+		},
+	}
+
+	composite := NewCompositeProvider(log.NewNopLogger(), streaming, fallback, time.Hour)
+
+	prices, err := composite.GetTickerPrices(pair)
+	if err != nil {
+		t.Fatalf("GetTickerPrices: %v", err)
+	}
+
+	tp, ok := prices[pair.String()]
+	if !ok || !tp.Price.Equal(sdk.MustNewDecFromStr("11.98")) {
+		t.Fatalf("expected fresh streamed price 11.98, got %v", prices)
+	}
+}
+
+type stubHTTPProvider struct {
+	tickers map[string]keepers.TickerPrice
+	candles map[string][]keepers.CandlePrice
+}
+
+func (s *stubHTTPProvider) GetTickerPrices(pairs ...keepers.CurrencyPair) (map[string]keepers.TickerPrice, error) {
+	out := make(map[string]keepers.TickerPrice, len(pairs))
+	for _, pair := range pairs {
+		if tp, ok := s.tickers[pair.String()]; ok {
+			out[pair.String()] = tp
+		}
+	}
+	return out, nil
+}
+
+func (s *stubHTTPProvider) GetCandlePrices(pairs ...keepers.CurrencyPair) (map[string][]keepers.CandlePrice, error) {
+	out := make(map[string][]keepers.CandlePrice, len(pairs))
+	for _, pair := range pairs {
+		if cp, ok := s.candles[pair.String()]; ok {
+			out[pair.String()] = cp
+		}
+	}
+	return out, nil
+}
+
+func gzipJSON(t *testing.T, s string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write([]byte(s)); err != nil {
+		t.Fatalf("failed to gzip test frame: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed to close gzip writer: %v", err)
+	}
+
+	return buf.Bytes()
+}
@@ -0,0 +1,117 @@
+package providers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/keepers"
+)
+
+// NewHuobiProvider returns a StreamingProvider subscribed to Huobi's market
+// ticker feed.
+func NewHuobiProvider(logger log.Logger) *BaseStreamProvider {
+	return newBaseStreamProvider(logger, "huobi", &huobiHandler{}).start()
+}
+
+type huobiHandler struct{}
+
+func (h *huobiHandler) endpoint() string {
+	return "wss://api.huobi.pro/ws"
+}
+
+func (h *huobiHandler) symbolForPair(pair keepers.CurrencyPair) string {
+	return strings.ToLower(pair.Base + pair.Quote)
+}
+
+// subscribeMessages returns one "sub" message per pair, since Huobi's market
+// WS API only accepts a single topic per subscribe frame.
+func (h *huobiHandler) subscribeMessages(pairs []keepers.CurrencyPair) ([][]byte, error) {
+	msgs := make([][]byte, len(pairs))
+
+	for i, pair := range pairs {
+		msg, err := json.Marshal(map[string]any{
+			"sub": fmt.Sprintf("market.%s.ticker", h.symbolForPair(pair)),
+			"id":  fmt.Sprintf("sub-%s", h.symbolForPair(pair)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("huobi: failed to encode subscribe message: %w", err)
+		}
+
+		msgs[i] = msg
+	}
+
+	return msgs, nil
+}
+
+// handleMessage decompresses Huobi's gzip-compressed frames, replies to
+// keepalive pings in-band, and records ticker updates.
+func (h *huobiHandler) handleMessage(data []byte, p *BaseStreamProvider) error {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("huobi: failed to open gzip reader: %w", err)
+	}
+	defer reader.Close()
+
+	plain, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("huobi: failed to decompress message: %w", err)
+	}
+
+	var ping struct {
+		Ping int64 `json:"ping"`
+	}
+	if err := json.Unmarshal(plain, &ping); err == nil && ping.Ping != 0 {
+		pong, err := json.Marshal(map[string]int64{"pong": ping.Ping})
+		if err != nil {
+			return fmt.Errorf("huobi: failed to encode pong: %w", err)
+		}
+
+		return p.send(pong)
+	}
+
+	var msg struct {
+		Channel string `json:"ch"`
+		Tick    struct {
+			Close float64 `json:"close"`
+			Vol   float64 `json:"vol"`
+		} `json:"tick"`
+	}
+	if err := json.Unmarshal(plain, &msg); err != nil {
+		return fmt.Errorf("huobi: failed to decode message: %w", err)
+	}
+
+	// ch looks like "market.atomusdt.ticker"; extract the symbol segment.
+	parts := strings.Split(msg.Channel, ".")
+	if len(parts) != 3 || parts[0] != "market" || parts[2] != "ticker" {
+		return nil
+	}
+
+	base, ok := p.baseForSymbol(parts[1])
+	if !ok {
+		return nil
+	}
+
+	price, err := sdk.NewDecFromStr(strconv.FormatFloat(msg.Tick.Close, 'f', -1, 64))
+	if err != nil {
+		return fmt.Errorf("huobi: failed to parse price %v: %w", msg.Tick.Close, err)
+	}
+
+	volume, err := sdk.NewDecFromStr(strconv.FormatFloat(msg.Tick.Vol, 'f', -1, 64))
+	if err != nil {
+		return fmt.Errorf("huobi: failed to parse volume %v: %w", msg.Tick.Vol, err)
+	}
+
+	p.recordTick(base, keepers.TickerPrice{Price: price, Volume: volume})
+	p.recordCandle(base, keepers.CandlePrice{Price: price, Volume: volume, TimeStamp: time.Now().UnixMilli()})
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/keepers"
+)
+
+// NewKrakenProvider returns a StreamingProvider subscribed to Kraken's
+// "ticker" channel.
+func NewKrakenProvider(logger log.Logger) *BaseStreamProvider {
+	return newBaseStreamProvider(logger, "kraken", &krakenHandler{}).start()
+}
+
+type krakenHandler struct{}
+
+func (h *krakenHandler) endpoint() string {
+	return "wss://ws.kraken.com"
+}
+
+func (h *krakenHandler) symbolForPair(pair keepers.CurrencyPair) string {
+	return pair.Base + "/" + pair.Quote
+}
+
+func (h *krakenHandler) subscribeMessages(pairs []keepers.CurrencyPair) ([][]byte, error) {
+	krakenPairs := make([]string, len(pairs))
+	for i, pair := range pairs {
+		krakenPairs[i] = h.symbolForPair(pair)
+	}
+
+	msg, err := json.Marshal(map[string]any{
+		"event": "subscribe",
+		"pair":  krakenPairs,
+		"subscription": map[string]string{
+			"name": "ticker",
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("kraken: failed to encode subscribe message: %w", err)
+	}
+
+	return [][]byte{msg}, nil
+}
+
+// handleMessage parses Kraken's ticker frame, which is a 4-element JSON
+// array: [channelID, payload, channelName, pairName]. Non-ticker frames
+// (subscription status objects, heartbeats) are plain JSON objects and fail
+// the array decode, so they're silently ignored.
+func (h *krakenHandler) handleMessage(data []byte, p *BaseStreamProvider) error {
+	var frame []json.RawMessage
+	if err := json.Unmarshal(data, &frame); err != nil {
+		return nil
+	}
+
+	if len(frame) != 4 {
+		return nil
+	}
+
+	var channelName, pairName string
+	if err := json.Unmarshal(frame[2], &channelName); err != nil {
+		return nil
+	}
+	if err := json.Unmarshal(frame[3], &pairName); err != nil {
+		return nil
+	}
+
+	if channelName != "ticker" {
+		return nil
+	}
+
+	var payload struct {
+		Close  []string `json:"c"`
+		Volume []string `json:"v"`
+	}
+	if err := json.Unmarshal(frame[1], &payload); err != nil {
+		return fmt.Errorf("kraken: failed to decode ticker payload: %w", err)
+	}
+
+	if len(payload.Close) == 0 || len(payload.Volume) == 0 {
+		return fmt.Errorf("kraken: ticker payload missing close/volume for %s", pairName)
+	}
+
+	base, ok := p.baseForSymbol(pairName)
+	if !ok {
+		return nil
+	}
+
+	price, err := sdk.NewDecFromStr(payload.Close[0])
+	if err != nil {
+		return fmt.Errorf("kraken: failed to parse price %q: %w", payload.Close[0], err)
+	}
+
+	volume, err := sdk.NewDecFromStr(payload.Volume[len(payload.Volume)-1])
+	if err != nil {
+		return fmt.Errorf("kraken: failed to parse volume %q: %w", payload.Volume[len(payload.Volume)-1], err)
+	}
+
+	p.recordTick(base, keepers.TickerPrice{Price: price, Volume: volume})
+	p.recordCandle(base, keepers.CandlePrice{Price: price, Volume: volume, TimeStamp: time.Now().UnixMilli()})
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/keepers"
+)
+
+// NewOKXProvider returns a StreamingProvider subscribed to OKX's "tickers"
+// channel.
+func NewOKXProvider(logger log.Logger) *BaseStreamProvider {
+	return newBaseStreamProvider(logger, "okx", &okxHandler{}).start()
+}
+
+type okxHandler struct{}
+
+func (h *okxHandler) endpoint() string {
+	return "wss://ws.okx.com:8443/ws/v5/public"
+}
+
+func (h *okxHandler) symbolForPair(pair keepers.CurrencyPair) string {
+	return pair.Base + "-" + pair.Quote
+}
+
+func (h *okxHandler) subscribeMessages(pairs []keepers.CurrencyPair) ([][]byte, error) {
+	args := make([]map[string]string, len(pairs))
+	for i, pair := range pairs {
+		args[i] = map[string]string{
+			"channel": "tickers",
+			"instId":  h.symbolForPair(pair),
+		}
+	}
+
+	msg, err := json.Marshal(map[string]any{
+		"op":   "subscribe",
+		"args": args,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("okx: failed to encode subscribe message: %w", err)
+	}
+
+	return [][]byte{msg}, nil
+}
+
+func (h *okxHandler) handleMessage(data []byte, p *BaseStreamProvider) error {
+	var msg struct {
+		Arg struct {
+			Channel string `json:"channel"`
+			InstID  string `json:"instId"`
+		} `json:"arg"`
+		Data []struct {
+			Last   string `json:"last"`
+			Vol24h string `json:"vol24h"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return fmt.Errorf("okx: failed to decode message: %w", err)
+	}
+
+	if msg.Arg.Channel != "tickers" || len(msg.Data) == 0 {
+		return nil
+	}
+
+	base, ok := p.baseForSymbol(msg.Arg.InstID)
+	if !ok {
+		return nil
+	}
+
+	tick := msg.Data[0]
+
+	price, err := sdk.NewDecFromStr(tick.Last)
+	if err != nil {
+		return fmt.Errorf("okx: failed to parse price %q: %w", tick.Last, err)
+	}
+
+	volume, err := sdk.NewDecFromStr(tick.Vol24h)
+	if err != nil {
+		return fmt.Errorf("okx: failed to parse volume %q: %w", tick.Vol24h, err)
+	}
+
+	p.recordTick(base, keepers.TickerPrice{Price: price, Volume: volume})
+	p.recordCandle(base, keepers.CandlePrice{Price: price, Volume: volume, TimeStamp: time.Now().UnixMilli()})
+
+	return nil
+}
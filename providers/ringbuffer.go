@@ -0,0 +1,55 @@
+package providers
+
+import "github.com/skip-mev/vote-ext-oracle-demo/keepers"
+
+// tickRingBuffer holds the last N ticker prices observed for a single base
+// asset over a WebSocket subscription. It is not safe for concurrent use;
+// callers are expected to hold the owning BaseStreamProvider's mtx.
+type tickRingBuffer struct {
+	ticks []keepers.TickerPrice
+	cap   int
+}
+
+func newTickRingBuffer(cap int) *tickRingBuffer {
+	return &tickRingBuffer{cap: cap}
+}
+
+func (b *tickRingBuffer) push(tp keepers.TickerPrice) {
+	b.ticks = append(b.ticks, tp)
+	if len(b.ticks) > b.cap {
+		b.ticks = b.ticks[len(b.ticks)-b.cap:]
+	}
+}
+
+// latest returns the most recently pushed tick, if any.
+func (b *tickRingBuffer) latest() (keepers.TickerPrice, bool) {
+	if len(b.ticks) == 0 {
+		return keepers.TickerPrice{}, false
+	}
+
+	return b.ticks[len(b.ticks)-1], true
+}
+
+// candleRingBuffer holds the last N candles observed for a single base asset.
+type candleRingBuffer struct {
+	candles []keepers.CandlePrice
+	cap     int
+}
+
+func newCandleRingBuffer(cap int) *candleRingBuffer {
+	return &candleRingBuffer{cap: cap}
+}
+
+func (b *candleRingBuffer) push(cp keepers.CandlePrice) {
+	b.candles = append(b.candles, cp)
+	if len(b.candles) > b.cap {
+		b.candles = b.candles[len(b.candles)-b.cap:]
+	}
+}
+
+// all returns every candle currently buffered, oldest first.
+func (b *candleRingBuffer) all() []keepers.CandlePrice {
+	out := make([]keepers.CandlePrice, len(b.candles))
+	copy(out, b.candles)
+	return out
+}
@@ -0,0 +1,384 @@
+// Package providers implements WebSocket-streaming price providers that
+// satisfy abci.Provider (GetTickerPrices/GetCandlePrices) from an in-memory
+// cache refreshed by long-lived subscriptions, instead of dialing out to an
+// HTTP endpoint on every ExtendVote.
+package providers
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/gorilla/websocket"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/keepers"
+)
+
+const (
+	// defaultRingBufferSize bounds how many ticks/candles are retained per
+	// base asset.
+	defaultRingBufferSize = 32
+
+	minReconnectBackoff = time.Second
+	maxReconnectBackoff = time.Minute
+)
+
+// StreamingProvider extends abci.Provider with explicit subscription control.
+// Unlike a pull-based Provider, GetTickerPrices/GetCandlePrices never block
+// on network I/O: they return whatever has most recently been pushed into
+// the provider's ring buffers by its background WebSocket connection.
+type StreamingProvider interface {
+	GetTickerPrices(...keepers.CurrencyPair) (map[string]keepers.TickerPrice, error)
+	GetCandlePrices(...keepers.CurrencyPair) (map[string][]keepers.CandlePrice, error)
+
+	// Subscribe opens (or extends) the provider's WebSocket subscriptions to
+	// include pairs. It is safe to call repeatedly as the supported pair set
+	// changes; pairs already subscribed to are ignored.
+	Subscribe(pairs ...keepers.CurrencyPair) error
+
+	// LastUpdated reports when base's ring buffer was last written to.
+	LastUpdated(base string) (time.Time, bool)
+
+	// Stop tears down the provider's connection and background goroutine.
+	Stop()
+}
+
+// wsHandler implements the exchange-specific pieces of a WebSocket price
+// feed: where to connect, how to ask for a currency pair's ticker updates,
+// and how to turn a raw frame into a tick. BaseStreamProvider owns
+// everything else: connection lifecycle, reconnection with backoff, symbol
+// bookkeeping, and ring buffer caching.
+type wsHandler interface {
+	// endpoint returns the WebSocket URL to dial.
+	endpoint() string
+
+	// symbolForPair returns the exchange's canonical symbol for pair, used
+	// both to build subscribe messages and to map incoming frames back to a
+	// base asset via BaseStreamProvider.baseForSymbol.
+	symbolForPair(pair keepers.CurrencyPair) string
+
+	// subscribeMessages returns the message(s) to send after connecting (or
+	// after Subscribe is called on an already-open connection) to subscribe
+	// to pairs.
+	subscribeMessages(pairs []keepers.CurrencyPair) ([][]byte, error)
+
+	// handleMessage parses a single inbound WebSocket frame, recording any
+	// ticks/candles it contains via p.recordTick/p.recordCandle. A non-nil
+	// error is logged but does not tear down the connection; only
+	// conn.ReadMessage errors trigger a reconnect.
+	handleMessage(data []byte, p *BaseStreamProvider) error
+}
+
+// BaseStreamProvider is the shared implementation behind every exchange's
+// StreamingProvider. It is not constructed directly outside this package;
+// use one of NewBinanceProvider, NewCoinbaseProvider, NewKrakenProvider,
+// NewHuobiProvider, or NewOKXProvider.
+type BaseStreamProvider struct {
+	logger  log.Logger
+	name    string
+	handler wsHandler
+	dialer  *websocket.Dialer
+
+	mtx          sync.Mutex
+	pairs        []keepers.CurrencyPair
+	symbolToBase map[string]string
+	ticks        map[string]*tickRingBuffer
+	candles      map[string]*candleRingBuffer
+	lastUpdated  map[string]time.Time
+
+	connMu sync.Mutex
+	conn   *websocket.Conn
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+}
+
+func newBaseStreamProvider(logger log.Logger, name string, handler wsHandler) *BaseStreamProvider {
+	return &BaseStreamProvider{
+		logger:       logger,
+		name:         name,
+		handler:      handler,
+		dialer:       websocket.DefaultDialer,
+		symbolToBase: make(map[string]string),
+		ticks:        make(map[string]*tickRingBuffer),
+		candles:      make(map[string]*candleRingBuffer),
+		lastUpdated:  make(map[string]time.Time),
+		stopCh:       make(chan struct{}),
+	}
+}
+
+// start launches the background connect/reconnect loop. Concrete
+// constructors call this; tests that exercise handleMessage/subscribeMessages
+// directly construct via newBaseStreamProvider without starting it, so they
+// never dial out.
+func (p *BaseStreamProvider) start() *BaseStreamProvider {
+	go p.run()
+	return p
+}
+
+func (p *BaseStreamProvider) GetTickerPrices(pairs ...keepers.CurrencyPair) (map[string]keepers.TickerPrice, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	out := make(map[string]keepers.TickerPrice, len(pairs))
+	for _, pair := range pairs {
+		buf, ok := p.ticks[pair.Base]
+		if !ok {
+			continue
+		}
+		if tp, ok := buf.latest(); ok {
+			out[pair.String()] = tp
+		}
+	}
+
+	if len(out) == 0 {
+		return nil, fmt.Errorf("%s: no cached ticker prices available for requested pairs", p.name)
+	}
+
+	return out, nil
+}
+
+func (p *BaseStreamProvider) GetCandlePrices(pairs ...keepers.CurrencyPair) (map[string][]keepers.CandlePrice, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	out := make(map[string][]keepers.CandlePrice, len(pairs))
+	for _, pair := range pairs {
+		buf, ok := p.candles[pair.Base]
+		if !ok {
+			continue
+		}
+		if candles := buf.all(); len(candles) > 0 {
+			out[pair.String()] = candles
+		}
+	}
+
+	if len(out) == 0 {
+		return nil, fmt.Errorf("%s: no cached candles available for requested pairs", p.name)
+	}
+
+	return out, nil
+}
+
+func (p *BaseStreamProvider) Subscribe(pairs ...keepers.CurrencyPair) error {
+	p.mtx.Lock()
+	var newPairs []keepers.CurrencyPair
+	for _, pair := range pairs {
+		if _, ok := p.ticks[pair.Base]; ok {
+			continue
+		}
+
+		p.ticks[pair.Base] = newTickRingBuffer(defaultRingBufferSize)
+		p.candles[pair.Base] = newCandleRingBuffer(defaultRingBufferSize)
+		p.symbolToBase[p.handler.symbolForPair(pair)] = pair.Base
+		p.pairs = append(p.pairs, pair)
+		newPairs = append(newPairs, pair)
+	}
+	p.mtx.Unlock()
+
+	if len(newPairs) == 0 {
+		return nil
+	}
+
+	return p.sendSubscribeMessages(newPairs)
+}
+
+func (p *BaseStreamProvider) LastUpdated(base string) (time.Time, bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	ts, ok := p.lastUpdated[base]
+	return ts, ok
+}
+
+func (p *BaseStreamProvider) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.stopCh)
+	})
+
+	p.connMu.Lock()
+	if p.conn != nil {
+		p.conn.Close()
+	}
+	p.connMu.Unlock()
+}
+
+func (p *BaseStreamProvider) baseForSymbol(symbol string) (string, bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	base, ok := p.symbolToBase[symbol]
+	return base, ok
+}
+
+func (p *BaseStreamProvider) recordTick(base string, tp keepers.TickerPrice) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	buf, ok := p.ticks[base]
+	if !ok {
+		buf = newTickRingBuffer(defaultRingBufferSize)
+		p.ticks[base] = buf
+	}
+
+	buf.push(tp)
+	p.lastUpdated[base] = time.Now()
+}
+
+func (p *BaseStreamProvider) recordCandle(base string, cp keepers.CandlePrice) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	buf, ok := p.candles[base]
+	if !ok {
+		buf = newCandleRingBuffer(defaultRingBufferSize)
+		p.candles[base] = buf
+	}
+
+	buf.push(cp)
+}
+
+// send writes raw directly to the active connection, if any. It is used by
+// handlers that must reply in-band, e.g. Huobi's ping/pong keepalive.
+//
+// gorilla/websocket permits only one concurrent writer per connection, so
+// every write goes through connMu: send, sendSubscribeMessages, and Stop's
+// close all serialize on it.
+func (p *BaseStreamProvider) send(raw []byte) error {
+	p.connMu.Lock()
+	defer p.connMu.Unlock()
+
+	return p.writeLocked(raw)
+}
+
+// writeLocked writes raw to the active connection. Callers must hold connMu.
+func (p *BaseStreamProvider) writeLocked(raw []byte) error {
+	if p.conn == nil {
+		return fmt.Errorf("%s: not connected", p.name)
+	}
+
+	return p.conn.WriteMessage(websocket.TextMessage, raw)
+}
+
+func (p *BaseStreamProvider) run() {
+	backoff := minReconnectBackoff
+
+	for {
+		select {
+		case <-p.stopCh:
+			return
+		default:
+		}
+
+		conn, _, err := p.dialer.Dial(p.handler.endpoint(), nil)
+		if err != nil {
+			p.logger.Error("failed to dial provider websocket", "provider", p.name, "err", err)
+			backoff = p.sleepBackoff(backoff)
+			continue
+		}
+
+		p.mtx.Lock()
+		pairs := append([]keepers.CurrencyPair(nil), p.pairs...)
+		p.mtx.Unlock()
+
+		// Publish conn before subscribing so sendSubscribeMessages writes
+		// through the same connMu-guarded path as send() and Subscribe(),
+		// rather than writing to conn directly while it's not yet p.conn.
+		p.connMu.Lock()
+		p.conn = conn
+		p.connMu.Unlock()
+
+		if len(pairs) > 0 {
+			if err := p.sendSubscribeMessages(pairs); err != nil {
+				p.logger.Error("failed to send subscribe message", "provider", p.name, "err", err)
+
+				p.connMu.Lock()
+				p.conn = nil
+				p.connMu.Unlock()
+
+				conn.Close()
+				backoff = p.sleepBackoff(backoff)
+				continue
+			}
+		}
+
+		backoff = minReconnectBackoff
+		p.readLoop(conn)
+
+		p.connMu.Lock()
+		p.conn = nil
+		p.connMu.Unlock()
+
+		select {
+		case <-p.stopCh:
+			return
+		default:
+		}
+
+		backoff = p.sleepBackoff(backoff)
+	}
+}
+
+func (p *BaseStreamProvider) readLoop(conn *websocket.Conn) {
+	defer conn.Close()
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			p.logger.Error("websocket read error, reconnecting", "provider", p.name, "err", err)
+			return
+		}
+
+		if err := p.handler.handleMessage(data, p); err != nil {
+			p.logger.Error("failed to handle websocket message", "provider", p.name, "err", err)
+		}
+	}
+}
+
+// sendSubscribeMessages writes the handler's subscribe frames for pairs to
+// the active connection, holding connMu for the duration so it can never
+// interleave with a concurrent send() (e.g. Huobi's pong keepalive replies
+// from the read loop): gorilla/websocket permits only one writer at a time.
+func (p *BaseStreamProvider) sendSubscribeMessages(pairs []keepers.CurrencyPair) error {
+	msgs, err := p.handler.subscribeMessages(pairs)
+	if err != nil {
+		return err
+	}
+
+	p.connMu.Lock()
+	defer p.connMu.Unlock()
+
+	if p.conn == nil {
+		// Not yet connected; run() will subscribe to the full pair set once
+		// it dials.
+		return nil
+	}
+
+	for _, msg := range msgs {
+		if err := p.writeLocked(msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sleepBackoff waits backoff+jitter (or until Stop is called) and returns the
+// next backoff duration, doubling up to maxReconnectBackoff.
+func (p *BaseStreamProvider) sleepBackoff(backoff time.Duration) time.Duration {
+	jitter := time.Duration(rand.Int63n(int64(backoff)/2 + 1))
+
+	select {
+	case <-time.After(backoff + jitter):
+	case <-p.stopCh:
+	}
+
+	next := backoff * 2
+	if next > maxReconnectBackoff {
+		next = maxReconnectBackoff
+	}
+
+	return next
+}
@@ -0,0 +1,25 @@
+// Package voteext defines the canonical data structures exchanged between
+// validators and proposers via CometBFT vote extensions. These types are kept
+// free of any particular wire format so that the abci handlers and the codec
+// implementations that (de)serialize them can depend on them independently.
+package voteext
+
+import (
+	abci "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// OracleVoteExtension defines the canonical vote extension structure.
+type OracleVoteExtension struct {
+	Height int64
+	Prices map[string]sdk.Dec
+}
+
+// StakeWeightedPrices defines the structure a proposer should use to calculate
+// and submit the stake-weighted prices for a given set of supported currency
+// pairs, in addition to the vote extensions used to calculate them. This is so
+// validators can verify the proposer's calculations.
+type StakeWeightedPrices struct {
+	StakeWeightedPrices map[string]sdk.Dec
+	ExtendedCommitInfo  abci.ExtendedCommitInfo
+}
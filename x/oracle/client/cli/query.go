@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/x/oracle/types"
+)
+
+// GetQueryCmd returns the CLI query commands for the oracle module.
+func GetQueryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Querying commands for the oracle module",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(
+		GetCmdQueryCurrencyPairs(),
+		GetCmdQueryProviderHealth(),
+		GetCmdQuerySpotPrice(),
+		GetCmdQueryPriceAt(),
+		GetCmdQueryTWAP(),
+	)
+
+	return cmd
+}
+
+// GetCmdQueryCurrencyPairs returns the CLI command for listing every
+// currency pair currently registered in the oracle module.
+func GetCmdQueryCurrencyPairs() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pairs",
+		Short: "List the currency pairs currently supported by the oracle module",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			bz, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/%s/pairs", types.StoreKey), nil)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintBytes(bz)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryProviderHealth returns the CLI command for querying a single
+// provider's health stats: last successful fetch and miss counter.
+func GetCmdQueryProviderHealth() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "provider-health [provider]",
+		Short: "Query a provider's last successful fetch time and miss counter",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			bz, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/%s/health/%s", types.StoreKey, args[0]), nil)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintBytes(bz)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQuerySpotPrice returns the CLI command for querying a base asset's
+// most recently finalized stake-weighted price.
+func GetCmdQuerySpotPrice() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "spot-price [base]",
+		Short: "Query the most recently finalized price for a base asset",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			bz, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/%s/spot/%s", types.StoreKey, args[0]), nil)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintBytes(bz)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryPriceAt returns the CLI command for querying a base asset's
+// finalized price at a specific historical block height.
+func GetCmdQueryPriceAt() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "price-at [base] [height]",
+		Short: "Query a base asset's finalized price at a historical block height",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			bz, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/%s/price-at/%s/%s", types.StoreKey, args[0], args[1]), nil)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintBytes(bz)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryTWAP returns the CLI command for querying a base asset's
+// time-weighted average price over a recent window of blocks.
+func GetCmdQueryTWAP() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "twap [base] [lookback-blocks]",
+		Short: "Query a base asset's time-weighted average price over the last N blocks",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			bz, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/%s/twap/%s/%s", types.StoreKey, args[0], args[1]), nil)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintBytes(bz)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
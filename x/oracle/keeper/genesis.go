@@ -0,0 +1,33 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/x/oracle/types"
+)
+
+// InitGenesis initializes the oracle module's state from genesis.
+func (k Keeper) InitGenesis(ctx sdk.Context, gs types.GenesisState) {
+	if err := k.SetParams(ctx, gs.Params); err != nil {
+		panic(err)
+	}
+
+	for _, pair := range gs.CurrencyPairs {
+		if err := k.AddCurrencyPair(ctx, pair); err != nil {
+			panic(err)
+		}
+	}
+
+	for _, provider := range gs.WhitelistedProviders {
+		k.WhitelistProvider(ctx, provider)
+	}
+}
+
+// ExportGenesis returns the oracle module's current state as a GenesisState.
+func (k Keeper) ExportGenesis(ctx sdk.Context) *types.GenesisState {
+	return &types.GenesisState{
+		Params:               k.GetParams(ctx),
+		CurrencyPairs:        k.GetSupportedPairs(ctx),
+		WhitelistedProviders: k.GetWhitelistedProviders(ctx),
+	}
+}
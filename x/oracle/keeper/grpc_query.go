@@ -0,0 +1,63 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/x/oracle/types"
+)
+
+// querier implements types.QueryServer against a Keeper and a fixed sdk.Context.
+//
+// NOTE: a generated gRPC QueryServer would take the context from the
+// incoming request; we thread it through explicitly here since we are not
+// generating the grpc.ServiceDesc boilerplate (see the NOTE on
+// types.QueryServer).
+type querier struct {
+	keeper Keeper
+	ctx    sdk.Context
+}
+
+// NewQuerier returns a types.QueryServer backed by k, bound to ctx.
+func NewQuerier(k Keeper, ctx sdk.Context) types.QueryServer {
+	return querier{keeper: k, ctx: ctx}
+}
+
+func (q querier) CurrencyPairs() types.QueryCurrencyPairsResponse {
+	return types.QueryCurrencyPairsResponse{CurrencyPairs: q.keeper.GetSupportedPairs(q.ctx)}
+}
+
+func (q querier) ProviderHealth(req types.QueryProviderHealthRequest) (types.QueryProviderHealthResponse, bool) {
+	health, ok := q.keeper.GetProviderHealth(q.ctx, req.Provider)
+	if !ok {
+		return types.QueryProviderHealthResponse{}, false
+	}
+
+	return types.QueryProviderHealthResponse{Health: health}, true
+}
+
+func (q querier) SpotPrice(req types.QuerySpotPriceRequest) (types.QuerySpotPriceResponse, bool) {
+	price, ok := q.keeper.GetSpotPrice(q.ctx, req.Base)
+	if !ok {
+		return types.QuerySpotPriceResponse{}, false
+	}
+
+	return types.QuerySpotPriceResponse{Price: price}, true
+}
+
+func (q querier) PriceAt(req types.QueryPriceAtRequest) (types.QueryPriceAtResponse, bool) {
+	price, ok := q.keeper.GetPriceAt(q.ctx, req.Base, req.Height)
+	if !ok {
+		return types.QueryPriceAtResponse{}, false
+	}
+
+	return types.QueryPriceAtResponse{Price: price}, true
+}
+
+func (q querier) TWAP(req types.QueryTWAPRequest) (types.QueryTWAPResponse, error) {
+	twap, err := q.keeper.GetTWAP(q.ctx, req.Base, req.LookbackBlocks)
+	if err != nil {
+		return types.QueryTWAPResponse{}, err
+	}
+
+	return types.QueryTWAPResponse{TWAP: twap}, nil
+}
@@ -0,0 +1,145 @@
+package keeper
+
+import (
+	"encoding/json"
+	"fmt"
+
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/x/oracle/types"
+)
+
+// GetSpotPrice returns the most recently finalized stake-weighted price for
+// base, if one has been recorded.
+func (k Keeper) GetSpotPrice(ctx sdk.Context, base string) (sdk.Dec, bool) {
+	acc := k.getTWAPAccumulator(ctx, base)
+	if acc.LastTimestamp.IsZero() {
+		return sdk.Dec{}, false
+	}
+
+	return acc.LastPrice, true
+}
+
+// GetPriceAt returns the finalized stake-weighted price for base at height,
+// if that height is still within the retention window (see
+// Params.PriceHistoryRetentionBlocks and PruneHistory).
+func (k Keeper) GetPriceAt(ctx sdk.Context, base string, height int64) (sdk.Dec, bool) {
+	record, found := k.getPriceRecord(ctx, base, height)
+	if !found {
+		return sdk.Dec{}, false
+	}
+
+	return record.Price, true
+}
+
+// GetTWAP returns the time-weighted average price of base over the last
+// lookbackBlocks blocks, computed as the difference between the current and
+// historical cumulative price accumulators, à la Uniswap V2. It returns an
+// error if base has no recorded price, or if the price at the start of the
+// lookback window has already been pruned.
+func (k Keeper) GetTWAP(ctx sdk.Context, base string, lookbackBlocks int64) (sdk.Dec, error) {
+	if lookbackBlocks <= 0 {
+		return sdk.Dec{}, fmt.Errorf("oracle: lookback blocks must be positive")
+	}
+
+	current := k.getTWAPAccumulator(ctx, base)
+	if current.LastTimestamp.IsZero() {
+		return sdk.Dec{}, fmt.Errorf("oracle: no recorded prices for %s", base)
+	}
+
+	pastHeight := current.LastHeight - lookbackBlocks
+
+	past, found := k.getPriceRecord(ctx, base, pastHeight)
+	if !found {
+		return sdk.Dec{}, fmt.Errorf("oracle: no price history for %s at height %d (pruned or not yet recorded)", base, pastHeight)
+	}
+
+	elapsedMillis := current.LastTimestamp.Sub(past.Timestamp).Milliseconds()
+	if elapsedMillis <= 0 {
+		return sdk.Dec{}, fmt.Errorf("oracle: non-positive elapsed time computing TWAP for %s", base)
+	}
+
+	return current.CumulativePrice.Sub(past.CumulativePrice).MulInt64(1000).QuoInt64(elapsedMillis), nil
+}
+
+// PruneHistory deletes price history entries for base older than
+// Params.PriceHistoryRetentionBlocks relative to the current block height.
+// It is invoked once per block from PreBlocker. The TWAP accumulator itself
+// is never pruned, only the historical snapshots GetTWAP/GetPriceAt read.
+func (k Keeper) PruneHistory(ctx sdk.Context, base string) {
+	cutoff := ctx.BlockHeight() - k.GetParams(ctx).PriceHistoryRetentionBlocks
+
+	store := ctx.KVStore(k.storeKey)
+	iter := storetypes.KVStorePrefixIterator(store, types.PriceHistoryBasePrefix(base))
+
+	var staleKeys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		var record types.PriceRecord
+		if err := json.Unmarshal(iter.Value(), &record); err != nil {
+			iter.Close()
+			panic(fmt.Errorf("failed to unmarshal price record during pruning: %w", err))
+		}
+
+		// PriceHistoryKey's big-endian height suffix means the prefix
+		// iterator visits records in ascending height order: once we reach
+		// one that's still within the retention window, every later record
+		// is too, so there's no need to keep scanning.
+		if record.Height >= cutoff {
+			break
+		}
+
+		staleKeys = append(staleKeys, append([]byte(nil), iter.Key()...))
+	}
+	iter.Close()
+
+	for _, key := range staleKeys {
+		store.Delete(key)
+	}
+}
+
+func (k Keeper) getTWAPAccumulator(ctx sdk.Context, base string) types.TWAPAccumulator {
+	bz := ctx.KVStore(k.storeKey).Get(types.TWAPKey(base))
+	if bz == nil {
+		return types.TWAPAccumulator{}
+	}
+
+	var acc types.TWAPAccumulator
+	if err := json.Unmarshal(bz, &acc); err != nil {
+		panic(fmt.Errorf("failed to unmarshal twap accumulator: %w", err))
+	}
+
+	return acc
+}
+
+func (k Keeper) setTWAPAccumulator(ctx sdk.Context, base string, acc types.TWAPAccumulator) {
+	bz, err := json.Marshal(acc)
+	if err != nil {
+		panic(fmt.Errorf("failed to marshal twap accumulator: %w", err))
+	}
+
+	ctx.KVStore(k.storeKey).Set(types.TWAPKey(base), bz)
+}
+
+func (k Keeper) getPriceRecord(ctx sdk.Context, base string, height int64) (types.PriceRecord, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.PriceHistoryKey(base, height))
+	if bz == nil {
+		return types.PriceRecord{}, false
+	}
+
+	var record types.PriceRecord
+	if err := json.Unmarshal(bz, &record); err != nil {
+		panic(fmt.Errorf("failed to unmarshal price record: %w", err))
+	}
+
+	return record, true
+}
+
+func (k Keeper) setPriceRecord(ctx sdk.Context, base string, record types.PriceRecord) {
+	bz, err := json.Marshal(record)
+	if err != nil {
+		panic(fmt.Errorf("failed to marshal price record: %w", err))
+	}
+
+	ctx.KVStore(k.storeKey).Set(types.PriceHistoryKey(base, record.Height), bz)
+}
@@ -0,0 +1,237 @@
+// Package keeper implements the oracle module's keeper: the
+// governance-managed currency-pair registry and provider whitelist that
+// replace keepers.FauxOracleKeeper's hardcoded ATOM/USD pair.
+package keeper
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/keepers"
+	"github.com/skip-mev/vote-ext-oracle-demo/x/oracle/types"
+)
+
+// Keeper manages the oracle module's on-chain state.
+//
+// NOTE: Params, the currency-pair registry, and provider health stats are
+// JSON-encoded in the KV store rather than backed by generated proto Msg
+// types, consistent with this demo's existing preference for plain
+// encoding/json over a full protobuf pipeline. A production deployment would
+// swap these for generated types the same way codec.ProtoCodec does for vote
+// extensions.
+type Keeper struct {
+	cdc      codec.BinaryCodec
+	storeKey storetypes.StoreKey
+
+	slashingHook types.SlashingHook
+	bankKeeper   types.BankKeeper
+}
+
+// NewKeeper returns a new oracle Keeper.
+func NewKeeper(cdc codec.BinaryCodec, storeKey storetypes.StoreKey) Keeper {
+	return Keeper{cdc: cdc, storeKey: storeKey}
+}
+
+// SetSlashingHook registers the hook invoked when a validator's oracle miss
+// rate exceeds Params.MaxMissRate. It follows the same pattern as the
+// staking module's SetHooks: it panics if called twice, and is meant to be
+// called once while constructing the app, e.g.
+// app.OracleKeeper = *oracleKeeper.SetSlashingHook(app.SlashingKeeper).
+func (k *Keeper) SetSlashingHook(hook types.SlashingHook) *Keeper {
+	if k.slashingHook != nil {
+		panic("cannot set oracle slashing hook twice")
+	}
+
+	k.slashingHook = hook
+	return k
+}
+
+// SetBankKeeper registers the bank keeper used to pay out oracle rewards.
+func (k *Keeper) SetBankKeeper(bk types.BankKeeper) *Keeper {
+	k.bankKeeper = bk
+	return k
+}
+
+func (k Keeper) GetParams(ctx sdk.Context) types.Params {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.ParamsKey)
+	if bz == nil {
+		return types.DefaultParams()
+	}
+
+	var params types.Params
+	if err := json.Unmarshal(bz, &params); err != nil {
+		panic(fmt.Errorf("failed to unmarshal oracle params: %w", err))
+	}
+
+	return params
+}
+
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	bz, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("failed to marshal oracle params: %w", err)
+	}
+
+	ctx.KVStore(k.storeKey).Set(types.ParamsKey, bz)
+	return nil
+}
+
+// GetSupportedPairs returns every currency pair currently registered via
+// AddCurrencyPairProposal/RemoveCurrencyPairProposal.
+func (k Keeper) GetSupportedPairs(ctx sdk.Context) []keepers.CurrencyPair {
+	store := ctx.KVStore(k.storeKey)
+
+	iter := storetypes.KVStorePrefixIterator(store, types.CurrencyPairKeyPrefix)
+	defer iter.Close()
+
+	var pairs []keepers.CurrencyPair
+	for ; iter.Valid(); iter.Next() {
+		var pair keepers.CurrencyPair
+		if err := json.Unmarshal(iter.Value(), &pair); err != nil {
+			panic(fmt.Errorf("failed to unmarshal currency pair: %w", err))
+		}
+
+		pairs = append(pairs, pair)
+	}
+
+	return pairs
+}
+
+// AddCurrencyPair registers pair in the oracle registry, keyed by its base
+// asset symbol. It is invoked by the AddCurrencyPairProposal handler.
+func (k Keeper) AddCurrencyPair(ctx sdk.Context, pair keepers.CurrencyPair) error {
+	bz, err := json.Marshal(pair)
+	if err != nil {
+		return fmt.Errorf("failed to marshal currency pair: %w", err)
+	}
+
+	ctx.KVStore(k.storeKey).Set(types.CurrencyPairKey(pair.Base), bz)
+	return nil
+}
+
+// RemoveCurrencyPair deregisters the currency pair for the given base asset
+// symbol. It is invoked by the RemoveCurrencyPairProposal handler.
+func (k Keeper) RemoveCurrencyPair(ctx sdk.Context, base string) {
+	ctx.KVStore(k.storeKey).Delete(types.CurrencyPairKey(base))
+}
+
+// IsProviderWhitelisted reports whether providerName has been approved via a
+// WhitelistProviderProposal.
+func (k Keeper) IsProviderWhitelisted(ctx sdk.Context, providerName string) bool {
+	return ctx.KVStore(k.storeKey).Has(types.ProviderKey(providerName))
+}
+
+// WhitelistProvider approves providerName as a trusted oracle price source.
+// It is invoked by the WhitelistProviderProposal handler.
+func (k Keeper) WhitelistProvider(ctx sdk.Context, providerName string) {
+	ctx.KVStore(k.storeKey).Set(types.ProviderKey(providerName), []byte{0x01})
+}
+
+// GetWhitelistedProviders returns the name of every currently whitelisted
+// provider, for ExportGenesis.
+func (k Keeper) GetWhitelistedProviders(ctx sdk.Context) []string {
+	store := ctx.KVStore(k.storeKey)
+
+	iter := storetypes.KVStorePrefixIterator(store, types.ProviderKeyPrefix)
+	defer iter.Close()
+
+	var providers []string
+	for ; iter.Valid(); iter.Next() {
+		providers = append(providers, string(iter.Key()[len(types.ProviderKeyPrefix):]))
+	}
+
+	return providers
+}
+
+// RecordProviderFetch updates providerName's health stats for a single
+// attempt to fetch prices from it: on success, the last successful fetch
+// timestamp is bumped and the miss counter is left untouched; on failure,
+// the miss counter is incremented. VoteExtHandler does not call it, so
+// health stats change only when a caller records a fetch through it.
+func (k Keeper) RecordProviderFetch(ctx sdk.Context, providerName string, success bool, now time.Time) {
+	health, _ := k.getProviderHealth(ctx, providerName)
+	health.Provider = providerName
+
+	if success {
+		health.LastSuccessfulFetch = now
+	} else {
+		health.MissCounter++
+	}
+
+	k.setProviderHealth(ctx, health)
+}
+
+// GetProviderHealth returns providerName's health stats, if any have been
+// recorded.
+func (k Keeper) GetProviderHealth(ctx sdk.Context, providerName string) (types.ProviderHealth, bool) {
+	return k.getProviderHealth(ctx, providerName)
+}
+
+func (k Keeper) getProviderHealth(ctx sdk.Context, providerName string) (types.ProviderHealth, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.ProviderHealthKey(providerName))
+	if bz == nil {
+		return types.ProviderHealth{Provider: providerName}, false
+	}
+
+	var health types.ProviderHealth
+	if err := json.Unmarshal(bz, &health); err != nil {
+		panic(fmt.Errorf("failed to unmarshal provider health: %w", err))
+	}
+
+	return health, true
+}
+
+func (k Keeper) setProviderHealth(ctx sdk.Context, health types.ProviderHealth) {
+	bz, err := json.Marshal(health)
+	if err != nil {
+		panic(fmt.Errorf("failed to marshal provider health: %w", err))
+	}
+
+	ctx.KVStore(k.storeKey).Set(types.ProviderHealthKey(health.Provider), bz)
+}
+
+// SetOraclePrices persists the proposer's finalized stake-weighted oracle
+// prices for the current block: a per-height PriceRecord for historical
+// lookups (GetPriceAt, GetTWAP), and an updated TWAPAccumulator per base for
+// O(1) spot price reads and as the basis for future TWAP snapshots.
+func (k Keeper) SetOraclePrices(ctx sdk.Context, prices map[string]sdk.Dec) error {
+	height := ctx.BlockHeight()
+	now := ctx.BlockTime()
+
+	for base, price := range prices {
+		acc := k.getTWAPAccumulator(ctx, base)
+
+		if acc.LastTimestamp.IsZero() {
+			acc.CumulativePrice = sdk.ZeroDec()
+		} else if elapsedMillis := now.Sub(acc.LastTimestamp).Milliseconds(); elapsedMillis > 0 {
+			// Accumulate the PREVIOUS price over the interval it was in
+			// effect, Uniswap V2-style, before overwriting it below.
+			acc.CumulativePrice = acc.CumulativePrice.Add(acc.LastPrice.MulInt64(elapsedMillis).QuoInt64(1000))
+		}
+
+		acc.LastPrice = price
+		acc.LastHeight = height
+		acc.LastTimestamp = now
+
+		k.setTWAPAccumulator(ctx, base, acc)
+		k.setPriceRecord(ctx, base, types.PriceRecord{
+			Price:           price,
+			CumulativePrice: acc.CumulativePrice,
+			Height:          height,
+			Timestamp:       now,
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/x/oracle/types"
+)
+
+// NewOracleProposalHandler returns a governance proposal handler for the
+// oracle module's proposal types: AddCurrencyPairProposal,
+// RemoveCurrencyPairProposal, WhitelistProviderProposal, and
+// SetOracleParamsProposal.
+func NewOracleProposalHandler(k Keeper) govv1beta1.Handler {
+	return func(ctx sdk.Context, content govv1beta1.Content) error {
+		switch c := content.(type) {
+		case *types.AddCurrencyPairProposal:
+			return k.AddCurrencyPair(ctx, c.Pair)
+
+		case *types.RemoveCurrencyPairProposal:
+			k.RemoveCurrencyPair(ctx, c.Base)
+			return nil
+
+		case *types.WhitelistProviderProposal:
+			k.WhitelistProvider(ctx, c.Provider)
+			return nil
+
+		case *types.SetOracleParamsProposal:
+			return k.SetParams(ctx, c.Params)
+
+		default:
+			return sdkerrors.ErrUnknownRequest.Wrapf("unrecognized oracle proposal content type: %T", c)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package keeper
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/x/oracle/types"
+)
+
+// Legacy query route names, reachable at "custom/oracle/<route>" via
+// baseapp's ABCI Query handler. See the NOTE on types.QueryServer for why
+// these back the CLI instead of a generated gRPC service.
+const (
+	QueryCurrencyPairs  = "pairs"
+	QueryProviderHealth = "health"
+	QuerySpotPrice      = "spot"
+	QueryPriceAt        = "price-at"
+	QueryTWAP           = "twap"
+)
+
+// NewLegacyQuerier returns a legacy sdk.Querier routing "custom/oracle/*" ABCI
+// queries to the oracle Keeper.
+func NewLegacyQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, _ abci.RequestQuery) ([]byte, error) {
+		if len(path) == 0 {
+			return nil, sdkerrors.ErrUnknownRequest.Wrap("no oracle query path provided")
+		}
+
+		q := NewQuerier(k, ctx)
+
+		switch path[0] {
+		case QueryCurrencyPairs:
+			return json.Marshal(q.CurrencyPairs())
+
+		case QueryProviderHealth:
+			if len(path) < 2 {
+				return nil, sdkerrors.ErrInvalidRequest.Wrap("provider-health query requires a provider name")
+			}
+
+			resp, ok := q.ProviderHealth(types.QueryProviderHealthRequest{Provider: path[1]})
+			if !ok {
+				return nil, sdkerrors.ErrKeyNotFound.Wrapf("no health stats recorded for provider %s", path[1])
+			}
+
+			return json.Marshal(resp)
+
+		case QuerySpotPrice:
+			if len(path) < 2 {
+				return nil, sdkerrors.ErrInvalidRequest.Wrap("spot-price query requires a base asset symbol")
+			}
+
+			resp, ok := q.SpotPrice(types.QuerySpotPriceRequest{Base: path[1]})
+			if !ok {
+				return nil, sdkerrors.ErrKeyNotFound.Wrapf("no recorded price for %s", path[1])
+			}
+
+			return json.Marshal(resp)
+
+		case QueryPriceAt:
+			if len(path) < 3 {
+				return nil, sdkerrors.ErrInvalidRequest.Wrap("price-at query requires a base asset symbol and height")
+			}
+
+			height, err := strconv.ParseInt(path[2], 10, 64)
+			if err != nil {
+				return nil, sdkerrors.ErrInvalidRequest.Wrapf("invalid height %q: %s", path[2], err)
+			}
+
+			resp, ok := q.PriceAt(types.QueryPriceAtRequest{Base: path[1], Height: height})
+			if !ok {
+				return nil, sdkerrors.ErrKeyNotFound.Wrapf("no price history for %s at height %d", path[1], height)
+			}
+
+			return json.Marshal(resp)
+
+		case QueryTWAP:
+			if len(path) < 3 {
+				return nil, sdkerrors.ErrInvalidRequest.Wrap("twap query requires a base asset symbol and a lookback block count")
+			}
+
+			lookbackBlocks, err := strconv.ParseInt(path[2], 10, 64)
+			if err != nil {
+				return nil, sdkerrors.ErrInvalidRequest.Wrapf("invalid lookback blocks %q: %s", path[2], err)
+			}
+
+			resp, err := q.TWAP(types.QueryTWAPRequest{Base: path[1], LookbackBlocks: lookbackBlocks})
+			if err != nil {
+				return nil, sdkerrors.ErrInvalidRequest.Wrap(err.Error())
+			}
+
+			return json.Marshal(resp)
+
+		default:
+			return nil, sdkerrors.ErrUnknownRequest.Wrapf("unknown oracle query path: %s", fmt.Sprint(path))
+		}
+	}
+}
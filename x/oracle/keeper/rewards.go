@@ -0,0 +1,72 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/x/oracle/types"
+)
+
+// GetRewardPool returns the oracle module account's current balance, the
+// pool DistributeOracleRewards pays out of.
+func (k Keeper) GetRewardPool(ctx sdk.Context) sdk.Coins {
+	if k.bankKeeper == nil {
+		return sdk.NewCoins()
+	}
+
+	return k.bankKeeper.GetAllBalances(ctx, authtypes.NewModuleAddress(types.ModuleName))
+}
+
+// DistributeOracleRewards pays pool out of the oracle module account,
+// pro-rata by voting power, to every validator in candidates whose oracle
+// vote-extension success rate over the last Params.MissWindow blocks meets
+// Params.MinRewardSuccessRate. Validators that haven't yet completed a full
+// MissWindow, or whose success rate falls short, receive nothing.
+func (k Keeper) DistributeOracleRewards(ctx sdk.Context, pool sdk.Coins, candidates []types.RewardCandidate) error {
+	if pool.IsZero() || k.bankKeeper == nil {
+		return nil
+	}
+
+	params := k.GetParams(ctx)
+
+	var eligible []types.RewardCandidate
+	var totalPower int64
+
+	for _, c := range candidates {
+		info := k.getValidatorOracleInfo(ctx, c.ConsAddress)
+		if info.IndexOffset < params.MissWindow {
+			continue
+		}
+
+		successRate := sdk.OneDec().Sub(sdk.NewDec(info.MissedBlocksCounter).QuoInt64(params.MissWindow))
+		if successRate.LT(params.MinRewardSuccessRate) {
+			continue
+		}
+
+		eligible = append(eligible, c)
+		totalPower += c.Power
+	}
+
+	if totalPower == 0 {
+		return nil
+	}
+
+	decPool := sdk.NewDecCoinsFromCoins(pool...)
+
+	for _, c := range eligible {
+		share := decPool.MulDecTruncate(sdk.NewDec(c.Power).QuoInt64(totalPower))
+
+		coins, _ := share.TruncateDecimal()
+		if coins.IsZero() {
+			continue
+		}
+
+		if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, c.AccAddress, coins); err != nil {
+			return fmt.Errorf("failed to distribute oracle reward to %s: %w", c.AccAddress, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package keeper
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/x/oracle/types"
+)
+
+// HandleValidatorVote records, for a single validator and block, whether it
+// participated in the oracle vote-extension protocol, and slashes it if its
+// rolling miss rate over the last Params.MissWindow blocks exceeds
+// Params.MaxMissRate.
+func (k Keeper) HandleValidatorVote(ctx sdk.Context, consAddr sdk.ConsAddress, power int64, missed bool) error {
+	params := k.GetParams(ctx)
+
+	info := k.getValidatorOracleInfo(ctx, consAddr)
+	index := info.IndexOffset % params.MissWindow
+
+	previouslyMissed := k.getMissBitmap(ctx, consAddr, index)
+	switch {
+	case !previouslyMissed && missed:
+		info.MissedBlocksCounter++
+	case previouslyMissed && !missed:
+		info.MissedBlocksCounter--
+	}
+
+	k.setMissBitmap(ctx, consAddr, index, missed)
+	info.IndexOffset++
+	k.setValidatorOracleInfo(ctx, consAddr, info)
+
+	if info.IndexOffset < params.MissWindow {
+		// Still filling the initial window; nothing to evaluate yet.
+		return nil
+	}
+
+	missRate := sdk.NewDec(info.MissedBlocksCounter).QuoInt64(params.MissWindow)
+	if missRate.GT(params.MaxMissRate) {
+		return k.slash(ctx, consAddr, power, missRate)
+	}
+
+	return nil
+}
+
+func (k Keeper) slash(ctx sdk.Context, consAddr sdk.ConsAddress, power int64, missRate sdk.Dec) error {
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeOracleSlash,
+		sdk.NewAttribute(types.AttributeKeyValidator, consAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyPower, fmt.Sprintf("%d", power)),
+		sdk.NewAttribute(types.AttributeKeyMissRate, missRate.String()),
+	))
+
+	// Reset the counter regardless of whether a hook is registered, so a
+	// validator that rejoins honestly isn't immediately slashed again next
+	// window. The miss bitmap backing the counter must be cleared in the
+	// same step: otherwise HandleValidatorVote would keep reading stale
+	// "missed" bits from before the reset, decrementing the now-zeroed
+	// counter below zero the next time the validator votes successfully.
+	info := k.getValidatorOracleInfo(ctx, consAddr)
+	info.MissedBlocksCounter = 0
+	k.setValidatorOracleInfo(ctx, consAddr, info)
+	k.clearMissBitmap(ctx, consAddr, k.GetParams(ctx).MissWindow)
+
+	if k.slashingHook == nil {
+		return nil
+	}
+
+	return k.slashingHook.SlashValidator(ctx, consAddr, power)
+}
+
+func (k Keeper) getValidatorOracleInfo(ctx sdk.Context, consAddr sdk.ConsAddress) types.ValidatorOracleInfo {
+	bz := ctx.KVStore(k.storeKey).Get(types.ValidatorOracleInfoKey(consAddr))
+	if bz == nil {
+		return types.ValidatorOracleInfo{}
+	}
+
+	var info types.ValidatorOracleInfo
+	if err := json.Unmarshal(bz, &info); err != nil {
+		panic(fmt.Errorf("failed to unmarshal validator oracle info: %w", err))
+	}
+
+	return info
+}
+
+func (k Keeper) setValidatorOracleInfo(ctx sdk.Context, consAddr sdk.ConsAddress, info types.ValidatorOracleInfo) {
+	bz, err := json.Marshal(info)
+	if err != nil {
+		panic(fmt.Errorf("failed to marshal validator oracle info: %w", err))
+	}
+
+	ctx.KVStore(k.storeKey).Set(types.ValidatorOracleInfoKey(consAddr), bz)
+}
+
+func (k Keeper) getMissBitmap(ctx sdk.Context, consAddr sdk.ConsAddress, index int64) bool {
+	bz := ctx.KVStore(k.storeKey).Get(types.ValidatorMissBitmapKey(consAddr, index))
+	return len(bz) > 0 && bz[0] == 1
+}
+
+func (k Keeper) setMissBitmap(ctx sdk.Context, consAddr sdk.ConsAddress, index int64, missed bool) {
+	var b byte
+	if missed {
+		b = 1
+	}
+
+	ctx.KVStore(k.storeKey).Set(types.ValidatorMissBitmapKey(consAddr, index), []byte{b})
+}
+
+// clearMissBitmap deletes every bit in consAddr's miss-bitmap window, so a
+// fresh window starts with no stale "missed" bits left over from before a
+// slash reset the counter they back.
+func (k Keeper) clearMissBitmap(ctx sdk.Context, consAddr sdk.ConsAddress, window int64) {
+	store := ctx.KVStore(k.storeKey)
+	for index := int64(0); index < window; index++ {
+		store.Delete(types.ValidatorMissBitmapKey(consAddr, index))
+	}
+}
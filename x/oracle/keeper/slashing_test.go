@@ -0,0 +1,139 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/log"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/x/oracle/types"
+)
+
+// newTestKeeper returns a Keeper backed by an in-memory store, and a context
+// to exercise it with. cdc is nil since nothing exercised here encodes via
+// codec.BinaryCodec; Keeper's state is JSON-encoded directly (see the NOTE
+// on Keeper).
+func newTestKeeper(t *testing.T) (Keeper, sdk.Context) {
+	t.Helper()
+
+	storeKey := storetypes.NewKVStoreKey(types.StoreKey)
+
+	ms := rootmulti.NewStore(dbm.NewMemDB(), log.NewNopLogger())
+	ms.MountStoreWithDB(storeKey, storetypes.StoreTypeIAVL, nil)
+	if err := ms.LoadLatestVersion(); err != nil {
+		t.Fatalf("failed to load store: %v", err)
+	}
+
+	ctx := sdk.NewContext(ms, cmtproto.Header{Height: 1, Time: time.Unix(1_700_000_000, 0)}, false, log.NewNopLogger())
+
+	return NewKeeper(nil, storeKey), ctx
+}
+
+func setMissWindow(t *testing.T, k Keeper, ctx sdk.Context, window int64, maxMissRate sdk.Dec) {
+	t.Helper()
+
+	params := types.DefaultParams()
+	params.MissWindow = window
+	params.MaxMissRate = maxMissRate
+
+	if err := k.SetParams(ctx, params); err != nil {
+		t.Fatalf("SetParams: %v", err)
+	}
+}
+
+// fakeSlashingHook records every validator it's asked to slash.
+type fakeSlashingHook struct {
+	slashed []sdk.ConsAddress
+}
+
+func (h *fakeSlashingHook) SlashValidator(_ sdk.Context, consAddr sdk.ConsAddress, _ int64) error {
+	h.slashed = append(h.slashed, consAddr)
+	return nil
+}
+
+func TestHandleValidatorVoteTracksMissesWithinWindow(t *testing.T) {
+	k, ctx := newTestKeeper(t)
+	setMissWindow(t, k, ctx, 4, sdk.NewDecWithPrec(50, 2)) // 50% max miss rate
+	consAddr := sdk.ConsAddress("validator-under-test")
+
+	// 1 miss out of 4 blocks (25%) should not trigger a slash.
+	missed := []bool{true, false, false, false}
+	for _, m := range missed {
+		if err := k.HandleValidatorVote(ctx, consAddr, 100, m); err != nil {
+			t.Fatalf("HandleValidatorVote: %v", err)
+		}
+	}
+
+	info := k.getValidatorOracleInfo(ctx, consAddr)
+	if info.MissedBlocksCounter != 1 {
+		t.Errorf("expected a miss counter of 1, got %d", info.MissedBlocksCounter)
+	}
+}
+
+func TestHandleValidatorVoteSlashesAboveMaxMissRate(t *testing.T) {
+	k, ctx := newTestKeeper(t)
+	setMissWindow(t, k, ctx, 4, sdk.NewDecWithPrec(50, 2)) // 50% max miss rate
+	consAddr := sdk.ConsAddress("validator-under-test")
+
+	hook := &fakeSlashingHook{}
+	k.SetSlashingHook(hook)
+
+	// 3 misses out of 4 blocks (75%) exceeds the 50% threshold.
+	missed := []bool{true, true, true, false}
+	for _, m := range missed {
+		if err := k.HandleValidatorVote(ctx, consAddr, 100, m); err != nil {
+			t.Fatalf("HandleValidatorVote: %v", err)
+		}
+	}
+
+	if len(hook.slashed) != 1 || !hook.slashed[0].Equals(consAddr) {
+		t.Fatalf("expected the validator to be slashed exactly once, got %v", hook.slashed)
+	}
+
+	info := k.getValidatorOracleInfo(ctx, consAddr)
+	if info.MissedBlocksCounter != 0 {
+		t.Errorf("expected the miss counter to be reset after a slash, got %d", info.MissedBlocksCounter)
+	}
+}
+
+// TestSlashResetsMissBitmap guards against a regression where slash() reset
+// MissedBlocksCounter but left the miss-bitmap entries for the just-ended
+// window in place: a subsequent successful vote at one of those indices
+// would read a stale "previously missed" bit and decrement the freshly
+// zeroed counter below zero.
+func TestSlashResetsMissBitmap(t *testing.T) {
+	k, ctx := newTestKeeper(t)
+	setMissWindow(t, k, ctx, 4, sdk.NewDecWithPrec(50, 2))
+	consAddr := sdk.ConsAddress("validator-under-test")
+
+	// Miss every block in the window so the validator gets slashed at the
+	// window boundary, and the bitmap is entirely "missed" beforehand.
+	for i := 0; i < 4; i++ {
+		if err := k.HandleValidatorVote(ctx, consAddr, 100, true); err != nil {
+			t.Fatalf("HandleValidatorVote: %v", err)
+		}
+	}
+
+	info := k.getValidatorOracleInfo(ctx, consAddr)
+	if info.MissedBlocksCounter != 0 {
+		t.Fatalf("expected the miss counter to be reset after the slash, got %d", info.MissedBlocksCounter)
+	}
+
+	// The index wraps back to 0 on the 5th vote. If the bitmap bit at index 0
+	// were still "missed" from before the reset, voting successfully here
+	// would decrement the already-zeroed counter to -1 instead of leaving it
+	// at 0.
+	if err := k.HandleValidatorVote(ctx, consAddr, 100, false); err != nil {
+		t.Fatalf("HandleValidatorVote: %v", err)
+	}
+
+	info = k.getValidatorOracleInfo(ctx, consAddr)
+	if info.MissedBlocksCounter != 0 {
+		t.Errorf("expected the miss counter to remain 0 after a successful vote post-slash, got %d", info.MissedBlocksCounter)
+	}
+}
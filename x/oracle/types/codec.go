@@ -0,0 +1,28 @@
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/codec/types"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
+)
+
+// RegisterLegacyAminoCodec registers the oracle module's governance proposal
+// types on the given amino codec.
+func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	cdc.RegisterConcrete(&AddCurrencyPairProposal{}, "oracle/AddCurrencyPairProposal", nil)
+	cdc.RegisterConcrete(&RemoveCurrencyPairProposal{}, "oracle/RemoveCurrencyPairProposal", nil)
+	cdc.RegisterConcrete(&WhitelistProviderProposal{}, "oracle/WhitelistProviderProposal", nil)
+	cdc.RegisterConcrete(&SetOracleParamsProposal{}, "oracle/SetOracleParamsProposal", nil)
+}
+
+// RegisterInterfaces registers the oracle module's governance proposal types
+// as implementations of govv1beta1.Content on the interface registry.
+func RegisterInterfaces(registry types.InterfaceRegistry) {
+	registry.RegisterImplementations(
+		(*govv1beta1.Content)(nil),
+		&AddCurrencyPairProposal{},
+		&RemoveCurrencyPairProposal{},
+		&WhitelistProviderProposal{},
+		&SetOracleParamsProposal{},
+	)
+}
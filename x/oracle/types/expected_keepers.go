@@ -0,0 +1,21 @@
+package types
+
+import sdk "github.com/cosmos/cosmos-sdk/types"
+
+// BankKeeper defines the subset of the bank module's keeper the oracle
+// module needs to pay out oracle rewards.
+type BankKeeper interface {
+	SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error
+
+	// GetAllBalances returns every coin held by addr, used to read the
+	// oracle module account's current reward pool.
+	GetAllBalances(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins
+}
+
+// RewardCandidate is a single validator considered for an oracle reward
+// distribution.
+type RewardCandidate struct {
+	ConsAddress sdk.ConsAddress
+	AccAddress  sdk.AccAddress
+	Power       int64
+}
@@ -0,0 +1,46 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/keepers"
+)
+
+// GenesisState defines the oracle module's genesis state.
+type GenesisState struct {
+	Params               Params
+	CurrencyPairs        []keepers.CurrencyPair
+	WhitelistedProviders []string
+}
+
+// DefaultGenesis returns the default oracle genesis state: the default
+// params and the same single ATOM/USD pair keepers.FauxOracleKeeper used to
+// hardcode.
+func DefaultGenesis() *GenesisState {
+	return &GenesisState{
+		Params: DefaultParams(),
+		CurrencyPairs: []keepers.CurrencyPair{
+			{Base: "ATOM", Quote: "USD"},
+		},
+	}
+}
+
+// Validate performs basic genesis state sanity checks.
+func (gs GenesisState) Validate() error {
+	if err := gs.Params.Validate(); err != nil {
+		return err
+	}
+
+	seen := make(map[string]struct{}, len(gs.CurrencyPairs))
+	for _, pair := range gs.CurrencyPairs {
+		if pair.Base == "" || pair.Quote == "" {
+			return fmt.Errorf("oracle: currency pair must specify both a base and a quote")
+		}
+		if _, ok := seen[pair.Base]; ok {
+			return fmt.Errorf("oracle: duplicate currency pair base %s", pair.Base)
+		}
+		seen[pair.Base] = struct{}{}
+	}
+
+	return nil
+}
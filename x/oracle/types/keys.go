@@ -0,0 +1,73 @@
+package types
+
+const (
+	// ModuleName is the name of the oracle module.
+	ModuleName = "oracle"
+
+	// StoreKey is the store key used by the oracle module's KV store.
+	StoreKey = ModuleName
+
+	// RouterKey is used to route governance proposals to the oracle module.
+	RouterKey = ModuleName
+)
+
+// KV store key prefixes for the oracle module.
+var (
+	ParamsKey                 = []byte{0x01}
+	CurrencyPairKeyPrefix     = []byte{0x02}
+	ProviderKeyPrefix         = []byte{0x03}
+	ProviderHealthKeyPrefix   = []byte{0x04}
+	ValidatorOracleInfoPrefix = []byte{0x05}
+	ValidatorMissBitmapPrefix = []byte{0x06}
+	PriceHistoryKeyPrefix     = []byte{0x07}
+	TWAPKeyPrefix             = []byte{0x08}
+)
+
+// CurrencyPairKey returns the store key for the currency pair registered
+// under the given base asset symbol.
+func CurrencyPairKey(base string) []byte {
+	return append(CurrencyPairKeyPrefix, []byte(base)...)
+}
+
+// ProviderKey returns the store key marking whether providerName is
+// whitelisted.
+func ProviderKey(providerName string) []byte {
+	return append(ProviderKeyPrefix, []byte(providerName)...)
+}
+
+// ProviderHealthKey returns the store key for providerName's health stats.
+func ProviderHealthKey(providerName string) []byte {
+	return append(ProviderHealthKeyPrefix, []byte(providerName)...)
+}
+
+// ValidatorOracleInfoKey returns the store key for a validator's oracle
+// vote-extension participation counters.
+func ValidatorOracleInfoKey(consAddr []byte) []byte {
+	return append(ValidatorOracleInfoPrefix, consAddr...)
+}
+
+// ValidatorMissBitmapKey returns the store key for the bit recording whether
+// a validator missed its oracle vote at the given MissWindow index.
+func ValidatorMissBitmapKey(consAddr []byte, index int64) []byte {
+	key := append(ValidatorMissBitmapPrefix, consAddr...)
+	return append(key, byte(index>>56), byte(index>>48), byte(index>>40), byte(index>>32), byte(index>>24), byte(index>>16), byte(index>>8), byte(index))
+}
+
+// PriceHistoryBasePrefix returns the store key prefix under which every
+// historical price record for the given base asset is stored, suitable for
+// a prefix iterator over PriceHistoryKey(base, *).
+func PriceHistoryBasePrefix(base string) []byte {
+	return append(PriceHistoryKeyPrefix, []byte(base+"/")...)
+}
+
+// PriceHistoryKey returns the store key for base's finalized stake-weighted
+// price at the given block height.
+func PriceHistoryKey(base string, height int64) []byte {
+	key := PriceHistoryBasePrefix(base)
+	return append(key, byte(height>>56), byte(height>>48), byte(height>>40), byte(height>>32), byte(height>>24), byte(height>>16), byte(height>>8), byte(height))
+}
+
+// TWAPKey returns the store key for base's running TWAP accumulator.
+func TWAPKey(base string) []byte {
+	return append(TWAPKeyPrefix, []byte(base)...)
+}
@@ -0,0 +1,157 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// StakeWeightAlgorithm selects how computeStakeWeightedOraclePrices combines
+// validator votes for a base asset into a single finalized price.
+type StakeWeightAlgorithm string
+
+const (
+	// StakeWeightAlgorithmMean computes a plain stake-weighted arithmetic
+	// mean of every vote, with no outlier rejection.
+	StakeWeightAlgorithmMean StakeWeightAlgorithm = "mean"
+
+	// StakeWeightAlgorithmMedian discards votes more than
+	// MedianDeviationMultiplier MADs from the unweighted median of all
+	// votes, then walks the remaining (price, stake) tuples in sorted order
+	// to find the price at which cumulative stake crosses half of total
+	// stake.
+	StakeWeightAlgorithmMedian StakeWeightAlgorithm = "median"
+
+	// StakeWeightAlgorithmTrimmedMean applies the same MAD-based outlier
+	// rejection as StakeWeightAlgorithmMedian, then computes a
+	// stake-weighted arithmetic mean of what remains.
+	StakeWeightAlgorithmTrimmedMean StakeWeightAlgorithm = "trimmed-mean"
+)
+
+// Validate reports whether a is one of the known algorithms.
+func (a StakeWeightAlgorithm) Validate() error {
+	switch a {
+	case StakeWeightAlgorithmMean, StakeWeightAlgorithmMedian, StakeWeightAlgorithmTrimmedMean:
+		return nil
+	default:
+		return fmt.Errorf("oracle: unknown stake weight algorithm %q", a)
+	}
+}
+
+// Params defines the tunable parameters of the oracle module. They are
+// governance-managed via SetOracleParamsProposal.
+type Params struct {
+	// Window is how far back, relative to the local wall-clock time at which
+	// ExtendVote starts fetching prices, candles are considered when
+	// computing TVWAP.
+	Window time.Duration
+
+	// MinProviders is the minimum number of non-deviant providers required to
+	// accept a base asset's price.
+	MinProviders uint32
+
+	// DeviationThreshold is the number of standard deviations from the
+	// cross-provider median beyond which a provider's price is discarded as
+	// an outlier.
+	DeviationThreshold sdk.Dec
+
+	// MissWindow is the number of blocks over which a validator's oracle
+	// vote-extension participation is tracked before its miss rate is
+	// evaluated against MaxMissRate.
+	MissWindow int64
+
+	// MaxMissRate is the fraction of MissWindow blocks a validator may miss
+	// (by not voting, or by voting a price that deviates beyond
+	// MaxVoteDeviation from the stake-weighted median) before it is slashed.
+	MaxMissRate sdk.Dec
+
+	// MaxVoteDeviation is the maximum relative deviation a validator's voted
+	// price for a base may have from the finalized stake-weighted price
+	// before that vote counts as a miss for participation-tracking purposes.
+	MaxVoteDeviation sdk.Dec
+
+	// MinRewardSuccessRate is the minimum fraction of successful votes, out
+	// of the last MissWindow blocks, a validator needs in order to receive a
+	// share of the oracle reward pool.
+	MinRewardSuccessRate sdk.Dec
+
+	// StakeWeightAlgorithm selects how validator votes are combined into a
+	// finalized stake-weighted price.
+	StakeWeightAlgorithm StakeWeightAlgorithm
+
+	// MedianDeviationMultiplier (K) bounds, in multiples of the median
+	// absolute deviation (MAD), how far a vote may be from the initial
+	// unweighted median before StakeWeightAlgorithmMedian and
+	// StakeWeightAlgorithmTrimmedMean discard it as an outlier.
+	MedianDeviationMultiplier sdk.Dec
+
+	// ComparePriceEpsilon bounds the acceptable relative numerical drift
+	// between a proposer's injected stake-weighted prices and a validator's
+	// own recomputation of them in ProcessProposal.
+	ComparePriceEpsilon sdk.Dec
+
+	// PriceHistoryRetentionBlocks is how many blocks of per-height price
+	// history Keeper.PruneHistory retains before deleting older
+	// prices/<base>/<height> entries. The running TWAP accumulator itself is
+	// never pruned, only the historical snapshots GetTWAP/GetPriceAt read.
+	PriceHistoryRetentionBlocks int64
+}
+
+// DefaultParams returns the oracle module's default parameters.
+func DefaultParams() Params {
+	return Params{
+		Window:               3 * time.Minute,
+		MinProviders:         1,
+		DeviationThreshold:   sdk.NewDec(3),
+		MissWindow:           1000,
+		MaxMissRate:          sdk.NewDecWithPrec(5, 2),  // 5%
+		MaxVoteDeviation:     sdk.NewDecWithPrec(1, 2),  // 1%
+		MinRewardSuccessRate: sdk.NewDecWithPrec(95, 2), // 95%
+
+		StakeWeightAlgorithm:      StakeWeightAlgorithmMedian,
+		MedianDeviationMultiplier: sdk.NewDec(3),
+		ComparePriceEpsilon:       sdk.NewDecWithPrec(1, 6), // 0.0001%
+
+		PriceHistoryRetentionBlocks: 100_000,
+	}
+}
+
+// Validate performs basic sanity checks on p.
+func (p Params) Validate() error {
+	if p.Window <= 0 {
+		return fmt.Errorf("oracle: window must be positive")
+	}
+	if p.MinProviders == 0 {
+		return fmt.Errorf("oracle: min providers must be positive")
+	}
+	if p.DeviationThreshold.IsNil() || p.DeviationThreshold.IsNegative() {
+		return fmt.Errorf("oracle: deviation threshold must be non-negative")
+	}
+	if p.MissWindow <= 0 {
+		return fmt.Errorf("oracle: miss window must be positive")
+	}
+	if p.MaxMissRate.IsNil() || p.MaxMissRate.IsNegative() || p.MaxMissRate.GT(sdk.OneDec()) {
+		return fmt.Errorf("oracle: max miss rate must be between 0 and 1")
+	}
+	if p.MaxVoteDeviation.IsNil() || p.MaxVoteDeviation.IsNegative() {
+		return fmt.Errorf("oracle: max vote deviation must be non-negative")
+	}
+	if p.MinRewardSuccessRate.IsNil() || p.MinRewardSuccessRate.IsNegative() || p.MinRewardSuccessRate.GT(sdk.OneDec()) {
+		return fmt.Errorf("oracle: min reward success rate must be between 0 and 1")
+	}
+	if err := p.StakeWeightAlgorithm.Validate(); err != nil {
+		return err
+	}
+	if p.MedianDeviationMultiplier.IsNil() || p.MedianDeviationMultiplier.IsNegative() {
+		return fmt.Errorf("oracle: median deviation multiplier must be non-negative")
+	}
+	if p.ComparePriceEpsilon.IsNil() || p.ComparePriceEpsilon.IsNegative() {
+		return fmt.Errorf("oracle: compare price epsilon must be non-negative")
+	}
+	if p.PriceHistoryRetentionBlocks <= 0 {
+		return fmt.Errorf("oracle: price history retention blocks must be positive")
+	}
+
+	return nil
+}
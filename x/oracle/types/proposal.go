@@ -0,0 +1,123 @@
+package types
+
+import (
+	"fmt"
+
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/keepers"
+)
+
+const (
+	ProposalTypeAddCurrencyPair    = "AddCurrencyPair"
+	ProposalTypeRemoveCurrencyPair = "RemoveCurrencyPair"
+	ProposalTypeWhitelistProvider  = "WhitelistProvider"
+	ProposalTypeSetOracleParams    = "SetOracleParams"
+)
+
+func init() {
+	govv1beta1.RegisterProposalType(ProposalTypeAddCurrencyPair)
+	govv1beta1.RegisterProposalType(ProposalTypeRemoveCurrencyPair)
+	govv1beta1.RegisterProposalType(ProposalTypeWhitelistProvider)
+	govv1beta1.RegisterProposalType(ProposalTypeSetOracleParams)
+}
+
+// AddCurrencyPairProposal is a governance proposal to add a new currency
+// pair to the oracle registry.
+type AddCurrencyPairProposal struct {
+	Title       string
+	Description string
+	Pair        keepers.CurrencyPair
+}
+
+func (p *AddCurrencyPairProposal) GetTitle() string       { return p.Title }
+func (p *AddCurrencyPairProposal) GetDescription() string { return p.Description }
+func (p *AddCurrencyPairProposal) ProposalRoute() string  { return RouterKey }
+func (p *AddCurrencyPairProposal) ProposalType() string   { return ProposalTypeAddCurrencyPair }
+
+func (p *AddCurrencyPairProposal) ValidateBasic() error {
+	if p.Pair.Base == "" || p.Pair.Quote == "" {
+		return fmt.Errorf("oracle: currency pair must specify both a base and a quote")
+	}
+
+	return govv1beta1.ValidateAbstract(p)
+}
+
+func (p *AddCurrencyPairProposal) String() string {
+	return fmt.Sprintf("Add Currency Pair Proposal:\n  Title: %s\n  Pair: %s/%s\n", p.Title, p.Pair.Base, p.Pair.Quote)
+}
+
+// RemoveCurrencyPairProposal is a governance proposal to remove a currency
+// pair from the oracle registry.
+type RemoveCurrencyPairProposal struct {
+	Title       string
+	Description string
+	Base        string
+}
+
+func (p *RemoveCurrencyPairProposal) GetTitle() string       { return p.Title }
+func (p *RemoveCurrencyPairProposal) GetDescription() string { return p.Description }
+func (p *RemoveCurrencyPairProposal) ProposalRoute() string  { return RouterKey }
+func (p *RemoveCurrencyPairProposal) ProposalType() string   { return ProposalTypeRemoveCurrencyPair }
+
+func (p *RemoveCurrencyPairProposal) ValidateBasic() error {
+	if p.Base == "" {
+		return fmt.Errorf("oracle: base asset symbol cannot be empty")
+	}
+
+	return govv1beta1.ValidateAbstract(p)
+}
+
+func (p *RemoveCurrencyPairProposal) String() string {
+	return fmt.Sprintf("Remove Currency Pair Proposal:\n  Title: %s\n  Base: %s\n", p.Title, p.Base)
+}
+
+// WhitelistProviderProposal is a governance proposal to whitelist an oracle
+// price provider, allowing VoteExtHandler to source prices from it.
+type WhitelistProviderProposal struct {
+	Title       string
+	Description string
+	Provider    string
+}
+
+func (p *WhitelistProviderProposal) GetTitle() string       { return p.Title }
+func (p *WhitelistProviderProposal) GetDescription() string { return p.Description }
+func (p *WhitelistProviderProposal) ProposalRoute() string  { return RouterKey }
+func (p *WhitelistProviderProposal) ProposalType() string   { return ProposalTypeWhitelistProvider }
+
+func (p *WhitelistProviderProposal) ValidateBasic() error {
+	if p.Provider == "" {
+		return fmt.Errorf("oracle: provider name cannot be empty")
+	}
+
+	return govv1beta1.ValidateAbstract(p)
+}
+
+func (p *WhitelistProviderProposal) String() string {
+	return fmt.Sprintf("Whitelist Provider Proposal:\n  Title: %s\n  Provider: %s\n", p.Title, p.Provider)
+}
+
+// SetOracleParamsProposal is a governance proposal to update the oracle
+// module's params (window, min providers, deviation threshold, etc.).
+type SetOracleParamsProposal struct {
+	Title       string
+	Description string
+	Params      Params
+}
+
+func (p *SetOracleParamsProposal) GetTitle() string       { return p.Title }
+func (p *SetOracleParamsProposal) GetDescription() string { return p.Description }
+func (p *SetOracleParamsProposal) ProposalRoute() string  { return RouterKey }
+func (p *SetOracleParamsProposal) ProposalType() string   { return ProposalTypeSetOracleParams }
+
+func (p *SetOracleParamsProposal) ValidateBasic() error {
+	if err := p.Params.Validate(); err != nil {
+		return err
+	}
+
+	return govv1beta1.ValidateAbstract(p)
+}
+
+func (p *SetOracleParamsProposal) String() string {
+	return fmt.Sprintf("Set Oracle Params Proposal:\n  Title: %s\n  Params: %+v\n", p.Title, p.Params)
+}
@@ -0,0 +1,83 @@
+package types
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/skip-mev/vote-ext-oracle-demo/keepers"
+)
+
+// ProviderHealth summarizes a whitelisted provider's recent fetch history.
+type ProviderHealth struct {
+	Provider            string
+	LastSuccessfulFetch time.Time
+	MissCounter         uint64
+}
+
+// QueryCurrencyPairsResponse is the response type for the CurrencyPairs
+// query.
+type QueryCurrencyPairsResponse struct {
+	CurrencyPairs []keepers.CurrencyPair
+}
+
+// QueryProviderHealthRequest is the request type for the ProviderHealth
+// query.
+type QueryProviderHealthRequest struct {
+	Provider string
+}
+
+// QueryProviderHealthResponse is the response type for the ProviderHealth
+// query.
+type QueryProviderHealthResponse struct {
+	Health ProviderHealth
+}
+
+// QuerySpotPriceRequest is the request type for the SpotPrice query.
+type QuerySpotPriceRequest struct {
+	Base string
+}
+
+// QuerySpotPriceResponse is the response type for the SpotPrice query.
+type QuerySpotPriceResponse struct {
+	Price sdk.Dec
+}
+
+// QueryPriceAtRequest is the request type for the PriceAt query.
+type QueryPriceAtRequest struct {
+	Base   string
+	Height int64
+}
+
+// QueryPriceAtResponse is the response type for the PriceAt query.
+type QueryPriceAtResponse struct {
+	Price sdk.Dec
+}
+
+// QueryTWAPRequest is the request type for the TWAP query.
+type QueryTWAPRequest struct {
+	Base           string
+	LookbackBlocks int64
+}
+
+// QueryTWAPResponse is the response type for the TWAP query.
+type QueryTWAPResponse struct {
+	TWAP sdk.Dec
+}
+
+// QueryServer defines the gRPC query service for the oracle module.
+//
+// NOTE: In a full deployment this interface, along with its request/response
+// types, would be generated from a query.proto via protoc-gen-gocosmos and
+// protoc-gen-grpc-gateway and registered on the gRPC query router. We hand
+// declare the contract here and implement it with the keeper package's
+// querier (see keeper.NewQuerier), which wraps a Keeper and an sdk.Context;
+// wiring up codegen and REST gateway routes is mechanical and omitted for
+// brevity.
+type QueryServer interface {
+	CurrencyPairs() QueryCurrencyPairsResponse
+	ProviderHealth(req QueryProviderHealthRequest) (QueryProviderHealthResponse, bool)
+	SpotPrice(req QuerySpotPriceRequest) (QuerySpotPriceResponse, bool)
+	PriceAt(req QueryPriceAtRequest) (QueryPriceAtResponse, bool)
+	TWAP(req QueryTWAPRequest) (QueryTWAPResponse, error)
+}
@@ -0,0 +1,34 @@
+package types
+
+import sdk "github.com/cosmos/cosmos-sdk/types"
+
+// ValidatorOracleInfo tracks a validator's oracle vote-extension
+// participation over the trailing Params.MissWindow blocks.
+type ValidatorOracleInfo struct {
+	// MissedBlocksCounter is the number of blocks, within the current
+	// MissWindow, in which the validator either did not vote or voted a
+	// price that deviated beyond MaxVoteDeviation from the stake-weighted
+	// median.
+	MissedBlocksCounter int64
+
+	// IndexOffset counts the blocks recorded for the validator; it
+	// increments every block and is never reset. Its value modulo
+	// Params.MissWindow is the validator's position within its MissWindow
+	// bitmap.
+	IndexOffset int64
+}
+
+// SlashingHook is implemented by a module (e.g. x/slashing) that wants to be
+// notified when a validator's oracle vote-extension miss rate exceeds
+// Params.MaxMissRate within a MissWindow.
+type SlashingHook interface {
+	SlashValidator(ctx sdk.Context, consAddr sdk.ConsAddress, power int64) error
+}
+
+// Event emitted when a validator is slashed for poor oracle participation.
+const (
+	EventTypeOracleSlash  = "oracle_slash"
+	AttributeKeyValidator = "validator"
+	AttributeKeyPower     = "power"
+	AttributeKeyMissRate  = "miss_rate"
+)
@@ -0,0 +1,32 @@
+package types
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// PriceRecord is a base asset's finalized stake-weighted price at a single
+// block height, persisted at PriceHistoryKey(base, height) so GetPriceAt and
+// GetTWAP can look back across blocks. CumulativePrice is the value of the
+// corresponding TWAPAccumulator.CumulativePrice at the time this record was
+// written, letting GetTWAP compute a TWAP over an arbitrary historical
+// window as the difference of two snapshots.
+type PriceRecord struct {
+	Price           sdk.Dec
+	CumulativePrice sdk.Dec
+	Height          int64
+	Timestamp       time.Time
+}
+
+// TWAPAccumulator tracks the running Uniswap V2-style cumulative price for a
+// base asset: CumulativePrice is the time integral of LastPrice up to
+// LastTimestamp, i.e. it grows by LastPrice * elapsed time every time the
+// price is updated. It is maintained by Keeper.SetOraclePrices and persisted
+// at TWAPKey(base).
+type TWAPAccumulator struct {
+	CumulativePrice sdk.Dec
+	LastPrice       sdk.Dec
+	LastHeight      int64
+	LastTimestamp   time.Time
+}